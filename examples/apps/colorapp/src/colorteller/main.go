@@ -54,8 +54,20 @@ func (h *pingHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 
 func main() {
 	log.Println("starting server, listening on port " + getServerPort())
-	xraySegmentNamer := xray.NewFixedSegmentNamer(fmt.Sprintf("%s-colorteller-%s", getStage(), getColor()))
-	http.Handle("/", xray.Handler(xraySegmentNamer, &colorHandler{}))
+	var xraySegmentNamer xray.SegmentNamer = xray.NewFixedSegmentNamer(fmt.Sprintf("%s-colorteller-%s", getStage(), getColor()))
+	if path := getScheduleConfigPath(); path != "" {
+		var err error
+		rollout, err = loadSchedule(path)
+		if err != nil {
+			log.Fatalf("failed to load schedule: %v", err)
+		}
+		log.Printf("following schedule %s with %d phase(s)", path, len(rollout.Phases))
+		xraySegmentNamer = &scheduledSegmentNamer{}
+		http.Handle("/", xray.Handler(xraySegmentNamer, &scheduledColorHandler{}))
+		http.Handle("/schedule", xray.Handler(xraySegmentNamer, &scheduleHandler{}))
+	} else {
+		http.Handle("/", xray.Handler(xraySegmentNamer, &colorHandler{}))
+	}
 	http.Handle("/ping", xray.Handler(xraySegmentNamer, &pingHandler{}))
 	http.ListenAndServe(":"+getServerPort(), nil)
 }
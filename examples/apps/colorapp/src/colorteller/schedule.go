@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aws/aws-xray-sdk-go/xray"
+)
+
+// phase is one step of a scheduled rollout. The colorteller answers with
+// Color for Duration, failing ErrorRate of requests with ErrorCode and adding
+// latency that ramps linearly from Latency to LatencyTo across the phase.
+type phase struct {
+	Name      string  `json:"name"`
+	Duration  string  `json:"duration"`
+	Color     string  `json:"color,omitempty"`
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	ErrorCode int     `json:"errorCode,omitempty"`
+	Latency   string  `json:"latency,omitempty"`
+	LatencyTo string  `json:"latencyTo,omitempty"`
+
+	duration  time.Duration
+	latency   time.Duration
+	latencyTo time.Duration
+}
+
+type schedule struct {
+	// Loop restarts the schedule after the last phase instead of staying in it.
+	Loop   bool     `json:"loop"`
+	Phases []*phase `json:"phases"`
+
+	start time.Time
+	total time.Duration
+}
+
+type phaseStatus struct {
+	Phase     string  `json:"phase"`
+	Index     int     `json:"index"`
+	Color     string  `json:"color"`
+	ErrorRate float64 `json:"errorRate"`
+	ErrorCode int     `json:"errorCode,omitempty"`
+	Latency   string  `json:"latency"`
+	Elapsed   string  `json:"elapsed"`
+	Remaining string  `json:"remaining,omitempty"`
+	Final     bool    `json:"final"`
+}
+
+var rollout *schedule
+
+func getScheduleConfigPath() string {
+	return os.Getenv("SCHEDULE_CONFIG")
+}
+
+func parseOptionalDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// loadSchedule reads the rollout schedule from path. The schedule starts as
+// soon as it is loaded.
+func loadSchedule(path string) (*schedule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s schedule
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	if len(s.Phases) == 0 {
+		return nil, fmt.Errorf("schedule %s has no phases", path)
+	}
+
+	for i, p := range s.Phases {
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("phase-%d", i)
+		}
+		if p.Color == "" {
+			p.Color = getColor()
+		}
+		if p.duration, err = time.ParseDuration(p.Duration); err != nil || p.duration <= 0 {
+			return nil, fmt.Errorf("phase %s needs a positive duration, got %q", p.Name, p.Duration)
+		}
+		if p.ErrorRate < 0 || p.ErrorRate > 1 {
+			return nil, fmt.Errorf("phase %s errorRate must be between 0.0 and 1.0", p.Name)
+		}
+		if p.ErrorRate > 0 && p.ErrorCode == 0 {
+			p.ErrorCode = http.StatusInternalServerError
+		}
+		if p.ErrorCode != 0 && (p.ErrorCode < 400 || p.ErrorCode > 599) {
+			return nil, fmt.Errorf("phase %s errorCode must be between 400 and 599, got %d", p.Name, p.ErrorCode)
+		}
+		if p.latency, err = parseOptionalDuration(p.Latency); err != nil || p.latency < 0 {
+			return nil, fmt.Errorf("phase %s needs a non-negative latency, got %q", p.Name, p.Latency)
+		}
+		if p.latencyTo, err = parseOptionalDuration(p.LatencyTo); err != nil || p.latencyTo < 0 {
+			return nil, fmt.Errorf("phase %s needs a non-negative latencyTo, got %q", p.Name, p.LatencyTo)
+		}
+		if p.LatencyTo == "" {
+			p.latencyTo = p.latency
+		}
+		s.total += p.duration
+	}
+	s.start = time.Now()
+	return &s, nil
+}
+
+// current returns the active phase and how far into it we are.
+func (s *schedule) current(now time.Time) (int, time.Duration, bool) {
+	elapsed := now.Sub(s.start)
+	if elapsed >= s.total {
+		if !s.Loop {
+			last := len(s.Phases) - 1
+			return last, s.Phases[last].duration + elapsed - s.total, true
+		}
+		elapsed %= s.total
+	}
+	for i, p := range s.Phases {
+		if elapsed < p.duration {
+			return i, elapsed, false
+		}
+		elapsed -= p.duration
+	}
+	// unreachable, elapsed is always within total here
+	return len(s.Phases) - 1, 0, true
+}
+
+// latencyAt interpolates between the phase's start and end latency.
+func (p *phase) latencyAt(into time.Duration) time.Duration {
+	if into >= p.duration {
+		return p.latencyTo
+	}
+	progress := float64(into) / float64(p.duration)
+	return p.latency + time.Duration(progress*float64(p.latencyTo-p.latency))
+}
+
+func (s *schedule) status(now time.Time) phaseStatus {
+	i, into, final := s.current(now)
+	p := s.Phases[i]
+	status := phaseStatus{
+		Phase:     p.Name,
+		Index:     i,
+		Color:     p.Color,
+		ErrorRate: p.ErrorRate,
+		ErrorCode: p.ErrorCode,
+		Latency:   p.latencyAt(into).String(),
+		Elapsed:   now.Sub(s.start).Round(time.Second).String(),
+		Final:     final,
+	}
+	if !final {
+		status.Remaining = (p.duration - into).Round(time.Second).String()
+	}
+	return status
+}
+
+// scheduledSegmentNamer names X-Ray segments after the color of the current
+// phase, as a fixed name would report the color the colorteller started with.
+type scheduledSegmentNamer struct{}
+
+func (n *scheduledSegmentNamer) Name(host string) string {
+	i, _, _ := rollout.current(time.Now())
+	return fmt.Sprintf("%s-colorteller-%s", getStage(), rollout.Phases[i].Color)
+}
+
+type scheduledColorHandler struct{}
+
+func (h *scheduledColorHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	i, into, _ := rollout.current(time.Now())
+	p := rollout.Phases[i]
+	xray.AddAnnotation(request.Context(), "phase", p.Name)
+	xray.AddAnnotation(request.Context(), "color", p.Color)
+	if latency := p.latencyAt(into); latency > 0 {
+		time.Sleep(latency)
+	}
+	if p.ErrorRate > 0 && rand.Float64() < p.ErrorRate {
+		log.Printf("color requested in phase %s, failing with %d", p.Name, p.ErrorCode)
+		writer.WriteHeader(p.ErrorCode)
+		return
+	}
+	log.Printf("color requested in phase %s, responding with %s", p.Name, p.Color)
+	fmt.Fprint(writer, p.Color)
+}
+
+type scheduleHandler struct{}
+
+func (h *scheduleHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(rollout.status(time.Now()))
+}
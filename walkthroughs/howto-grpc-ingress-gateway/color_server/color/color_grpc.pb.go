@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.17.1
+// source: color.proto
+
+package color
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ColorService_GetColor_FullMethodName     = "/color.ColorService/GetColor"
+	ColorService_SetColor_FullMethodName     = "/color.ColorService/SetColor"
+	ColorService_GetFlakiness_FullMethodName = "/color.ColorService/GetFlakiness"
+	ColorService_SetFlakiness_FullMethodName = "/color.ColorService/SetFlakiness"
+)
+
+// ColorServiceClient is the client API for ColorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ColorServiceClient interface {
+	GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error)
+	SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error)
+	GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error)
+	SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error)
+}
+
+type colorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewColorServiceClient(cc grpc.ClientConnInterface) ColorServiceClient {
+	return &colorServiceClient{cc}
+}
+
+func (c *colorServiceClient) GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error) {
+	out := new(GetColorResponse)
+	err := c.cc.Invoke(ctx, ColorService_GetColor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error) {
+	out := new(SetColorResponse)
+	err := c.cc.Invoke(ctx, ColorService_SetColor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error) {
+	out := new(GetFlakinessResponse)
+	err := c.cc.Invoke(ctx, ColorService_GetFlakiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error) {
+	out := new(SetFlakinessResponse)
+	err := c.cc.Invoke(ctx, ColorService_SetFlakiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ColorServiceServer is the server API for ColorService service.
+// All implementations must embed UnimplementedColorServiceServer
+// for forward compatibility
+type ColorServiceServer interface {
+	GetColor(context.Context, *GetColorRequest) (*GetColorResponse, error)
+	SetColor(context.Context, *SetColorRequest) (*SetColorResponse, error)
+	GetFlakiness(context.Context, *GetFlakinessRequest) (*GetFlakinessResponse, error)
+	SetFlakiness(context.Context, *SetFlakinessRequest) (*SetFlakinessResponse, error)
+	mustEmbedUnimplementedColorServiceServer()
+}
+
+// UnimplementedColorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedColorServiceServer struct {
+}
+
+func (UnimplementedColorServiceServer) GetColor(context.Context, *GetColorRequest) (*GetColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetColor not implemented")
+}
+func (UnimplementedColorServiceServer) SetColor(context.Context, *SetColorRequest) (*SetColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetColor not implemented")
+}
+func (UnimplementedColorServiceServer) GetFlakiness(context.Context, *GetFlakinessRequest) (*GetFlakinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlakiness not implemented")
+}
+func (UnimplementedColorServiceServer) SetFlakiness(context.Context, *SetFlakinessRequest) (*SetFlakinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFlakiness not implemented")
+}
+func (UnimplementedColorServiceServer) mustEmbedUnimplementedColorServiceServer() {}
+
+// UnsafeColorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ColorServiceServer will
+// result in compilation errors.
+type UnsafeColorServiceServer interface {
+	mustEmbedUnimplementedColorServiceServer()
+}
+
+func RegisterColorServiceServer(s grpc.ServiceRegistrar, srv ColorServiceServer) {
+	s.RegisterService(&ColorService_ServiceDesc, srv)
+}
+
+func _ColorService_GetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).GetColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_GetColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).GetColor(ctx, req.(*GetColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_SetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).SetColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_SetColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).SetColor(ctx, req.(*SetColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_GetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlakinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).GetFlakiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_GetFlakiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).GetFlakiness(ctx, req.(*GetFlakinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_SetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFlakinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).SetFlakiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_SetFlakiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).SetFlakiness(ctx, req.(*SetFlakinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ColorService_ServiceDesc is the grpc.ServiceDesc for ColorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ColorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "color.ColorService",
+	HandlerType: (*ColorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetColor",
+			Handler:    _ColorService_GetColor_Handler,
+		},
+		{
+			MethodName: "SetColor",
+			Handler:    _ColorService_SetColor_Handler,
+		},
+		{
+			MethodName: "GetFlakiness",
+			Handler:    _ColorService_GetFlakiness_Handler,
+		},
+		{
+			MethodName: "SetFlakiness",
+			Handler:    _ColorService_SetFlakiness_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "color.proto",
+}
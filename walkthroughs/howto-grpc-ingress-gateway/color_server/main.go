@@ -10,6 +10,7 @@ import (
 
 	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/admin"
 	"google.golang.org/grpc/codes"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -17,15 +18,16 @@ import (
 )
 
 type colorServer struct {
+	pb.UnimplementedColorServiceServer
 	color     pb.Color
-	flakiness pb.Flakiness
+	flakiness *pb.Flakiness
 }
 
 func (s *colorServer) GetColor(ctx context.Context, in *pb.GetColorRequest) (*pb.GetColorResponse, error) {
 	log.Printf("Received GetColor request")
 	// test for random flakiness in the api
-	if rand.Float32() < s.flakiness.Rate {
-		code := codes.Code(s.flakiness.Code)
+	if rand.Float32() < s.flakiness.GetRate() {
+		code := codes.Code(s.flakiness.GetCode())
 		return nil, status.Error(code, code.String())
 	}
 	return &pb.GetColorResponse{Color: s.color}, nil
@@ -40,14 +42,17 @@ func (s *colorServer) SetColor(ctx context.Context, in *pb.SetColorRequest) (*pb
 
 func (s *colorServer) GetFlakiness(ctx context.Context, in *pb.GetFlakinessRequest) (*pb.GetFlakinessResponse, error) {
 	log.Printf("Received GetFlakiness request")
-	return &pb.GetFlakinessResponse{Flakiness: &s.flakiness}, nil
+	return &pb.GetFlakinessResponse{Flakiness: s.flakiness}, nil
 }
 
 func (s *colorServer) SetFlakiness(ctx context.Context, in *pb.SetFlakinessRequest) (*pb.SetFlakinessResponse, error) {
 	log.Printf("Received SetFlakiness request: %v", in)
 	oldFlakiness := s.flakiness
-	s.flakiness = *in.Flakiness
-	return &pb.SetFlakinessResponse{Flakiness: &oldFlakiness}, nil
+	s.flakiness = in.Flakiness
+	if s.flakiness == nil {
+		s.flakiness = &pb.Flakiness{}
+	}
+	return &pb.SetFlakinessResponse{Flakiness: oldFlakiness}, nil
 }
 
 func (s *colorServer) Check(ctx context.Context, in *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
@@ -71,16 +76,29 @@ func main() {
 	}
 	log.Printf("COLOR is: %v", color)
 	log.Printf("PORT is: %v", port)
+	reflectionOn := os.Getenv("GRPC_REFLECTION") != "false"
+	adminOn := os.Getenv("GRPC_ADMIN") == "true"
+	log.Printf("GRPC_REFLECTION is: %v", reflectionOn)
+	log.Printf("GRPC_ADMIN is: %v", adminOn)
 	lis, err := net.Listen("tcp", "0.0.0.0:"+port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
 	colorValue := pb.Color(pb.Color_value[strings.ToUpper(color)])
-	c := colorServer{color: colorValue}
+	c := colorServer{color: colorValue, flakiness: &pb.Flakiness{}}
 	pb.RegisterColorServiceServer(s, &c)
 	health.RegisterHealthServer(s, &c)
-	reflection.Register(s)
+	if reflectionOn {
+		reflection.Register(s)
+	}
+	if adminOn {
+		cleanup, err := admin.Register(s)
+		if err != nil {
+			log.Fatalf("failed to register the admin services: %v", err)
+		}
+		defer cleanup()
+	}
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -2,4 +2,7 @@
 
 set -e
 
-protoc ./color.proto --go_out=plugins=grpc:./color_server/color
+# The checked-in code was generated with protoc 3.17.1 and these plugins:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.31.0
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
+protoc ./color.proto --go_out=./color_server/color --go-grpc_out=./color_server/color
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_client/color"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// weightedName is the balancer LB_POLICY=weighted picks. gRPC's own
+// weighted_round_robin takes its weights from load reports of the servers,
+// this one from COLOR_HOST.
+const weightedName = "color_weighted_round_robin"
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(weightedName, weightedPickerBuilder{}, base.Config{HealthCheck: true}))
+}
+
+type weightKey struct{}
+
+func addressWeight(addr resolver.Address) int {
+	if w, ok := addr.BalancerAttributes.Value(weightKey{}).(int); ok {
+		return w
+	}
+	return 1
+}
+
+type weightedPickerBuilder struct{}
+
+func (weightedPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &weightedPicker{}
+	for sc, scInfo := range info.ReadySCs {
+		p.backends = append(p.backends, &weightedBackend{subConn: sc, weight: addressWeight(scInfo.Address)})
+	}
+	return p
+}
+
+type weightedBackend struct {
+	subConn balancer.SubConn
+	weight  int
+	current int
+}
+
+// weightedPicker spreads calls over the ready backends in proportion to their
+// weights, interleaving them the way nginx's smooth weighted round robin does
+// rather than sending runs of calls to the same backend.
+type weightedPicker struct {
+	mutex    sync.Mutex
+	backends []*weightedBackend
+}
+
+func (p *weightedPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var best *weightedBackend
+	total := 0
+	for _, b := range p.backends {
+		b.current += b.weight
+		total += b.weight
+		if best == nil || b.current > best.current {
+			best = b
+		}
+	}
+	best.current -= total
+	return balancer.PickResult{SubConn: best.subConn}, nil
+}
+
+// lbPolicies maps LB_POLICY to gRPC's balancer names.
+var lbPolicies = map[string]string{
+	"pick_first":  "pick_first",
+	"round_robin": "round_robin",
+	"weighted":    weightedName,
+}
+
+// getLbPolicy reads LB_POLICY. gRPC picks the first address it can connect to
+// and sends every call over that one connection when it isn't set.
+func getLbPolicy() (string, error) {
+	policy := os.Getenv("LB_POLICY")
+	if policy == "" {
+		return "", nil
+	}
+	name, ok := lbPolicies[policy]
+	if !ok {
+		return "", fmt.Errorf("invalid LB_POLICY %q, it must be pick_first, round_robin or weighted", policy)
+	}
+	return name, nil
+}
+
+// getTarget turns a comma separated COLOR_HOST, e.g.
+// red.howto-grpc.local:8080=3,blue.howto-grpc.local:8080=1, into a target
+// for a resolver that hands out that list, with the optional weights for
+// LB_POLICY=weighted. Any other COLOR_HOST is dialed as it is, so that
+// dns:///color_server.howto-grpc.local:8080 balances over every address DNS
+// returns.
+func getTarget(colorHost, lbPolicy string) (string, []grpc.DialOption, error) {
+	if targetHost(colorHost) != "" {
+		return colorHost, nil, nil
+	}
+	var addrs []resolver.Address
+	for _, target := range strings.Split(colorHost, ",") {
+		target = strings.TrimSpace(target)
+		weight := 1
+		if i := strings.LastIndex(target, "="); i >= 0 {
+			w, err := strconv.Atoi(target[i+1:])
+			if err != nil || w <= 0 {
+				return "", nil, fmt.Errorf("invalid weight in COLOR_HOST target %q", target)
+			}
+			if lbPolicy != weightedName {
+				return "", nil, fmt.Errorf("COLOR_HOST target %q has a weight, which needs LB_POLICY=weighted", target)
+			}
+			target, weight = target[:i], w
+		}
+		host, _, err := net.SplitHostPort(target)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid COLOR_HOST target %q: %v", target, err)
+		}
+		addrs = append(addrs, resolver.Address{
+			Addr:               target,
+			ServerName:         host,
+			BalancerAttributes: attributes.New(weightKey{}, weight),
+		})
+	}
+	r := manual.NewBuilderWithScheme("colors")
+	r.InitialState(resolver.State{Addresses: addrs})
+	return r.Scheme() + ":///color_server", []grpc.DialOption{grpc.WithResolvers(r)}, nil
+}
+
+// backendStats counts the calls answered by one backend. Share is its
+// percentage of all calls.
+type backendStats struct {
+	Calls  int            `json:"calls"`
+	Share  float64        `json:"share"`
+	Codes  map[string]int `json:"codes"`
+	Colors map[string]int `json:"colors,omitempty"`
+}
+
+type distributionInfo struct {
+	Total    int                      `json:"total"`
+	Backends map[string]*backendStats `json:"backends"`
+}
+
+type backendKey struct{}
+
+// backendCall is what distribution learns about one call as it goes.
+type backendCall struct {
+	address string
+	color   string
+}
+
+// distribution is a stats.Handler that counts calls by the backend address
+// that answered them, and the colors each backend returned.
+type distribution struct {
+	mutex    sync.Mutex
+	total    int
+	backends map[string]*backendStats
+}
+
+func newDistribution() *distribution {
+	return &distribution{backends: make(map[string]*backendStats)}
+}
+
+func (d *distribution) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, backendKey{}, &backendCall{})
+}
+
+func (d *distribution) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	call, ok := ctx.Value(backendKey{}).(*backendCall)
+	if !ok || !s.IsClient() {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.OutHeader:
+		if s.RemoteAddr != nil {
+			call.address = s.RemoteAddr.String()
+			if result, ok := ctx.Value(callResultKey{}).(*callResult); ok {
+				result.setBackend(call.address)
+			}
+		}
+	case *stats.InPayload:
+		if resp, ok := s.Payload.(interface{ GetInfo() *pb.ColorInfo }); ok && resp.GetInfo().GetName() != "" {
+			call.color = strings.ToUpper(resp.GetInfo().GetName())
+		} else if resp, ok := s.Payload.(interface{ GetColor() pb.Color }); ok {
+			call.color = resp.GetColor().String()
+		}
+	case *stats.End:
+		if call.address == "" {
+			// the call never made it to a backend
+			return
+		}
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		b := d.backends[call.address]
+		if b == nil {
+			b = &backendStats{Codes: make(map[string]int), Colors: make(map[string]int)}
+			d.backends[call.address] = b
+		}
+		d.total++
+		b.Calls++
+		b.Codes[status.Code(s.Error).String()]++
+		if call.color != "" {
+			b.Colors[call.color]++
+		}
+	}
+}
+
+func (d *distribution) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (d *distribution) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+// distributionHandler reports how the calls made so far were spread over
+// the backends, and DELETE starts counting again.
+func distributionHandler(d *distribution) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		d.mutex.Lock()
+		if req.Method == http.MethodDelete {
+			d.total = 0
+			d.backends = make(map[string]*backendStats)
+		}
+		info := distributionInfo{Total: d.total, Backends: make(map[string]*backendStats)}
+		for addr, b := range d.backends {
+			copied := &backendStats{
+				Calls:  b.Calls,
+				Share:  math.Round(1000*float64(b.Calls)/float64(d.total)) / 10,
+				Codes:  make(map[string]int),
+				Colors: make(map[string]int),
+			}
+			for k, v := range b.Codes {
+				copied.Codes[k] = v
+			}
+			for k, v := range b.Colors {
+				copied.Colors[k] = v
+			}
+			info.Backends[addr] = copied
+		}
+		d.mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(info)
+	}
+}
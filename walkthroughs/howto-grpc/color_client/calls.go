@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// defaultForwardHeaders are copied from each HTTP request to the gRPC calls
+// made for it. They only carry tracing: the x-envoy-* headers would let anyone
+// reaching the Color Client override the mesh's retry and timeout policy, as
+// the color client's Envoy trusts requests from its own app, so they have to
+// be listed in FORWARD_HEADERS. W3C trace context is not copied, but continued
+// by the tracing interceptors.
+var defaultForwardHeaders = []string{
+	"x-request-id",
+	"x-amzn-trace-id",
+	"x-b3-traceid",
+	"x-b3-spanid",
+	"x-b3-parentspanid",
+	"x-b3-sampled",
+	"x-b3-flags",
+	"b3",
+}
+
+// getForwardHeaders returns the headers to forward, with any extra ones from
+// the comma separated FORWARD_HEADERS.
+func getForwardHeaders() map[string]bool {
+	headers := make(map[string]bool)
+	for _, h := range defaultForwardHeaders {
+		headers[h] = true
+	}
+	for _, h := range strings.Split(os.Getenv("FORWARD_HEADERS"), ",") {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			headers[h] = true
+		}
+	}
+	return headers
+}
+
+var forwardHeaders = getForwardHeaders()
+
+func forwardedMetadata(req *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range req.Header {
+		if key = strings.ToLower(key); forwardHeaders[key] {
+			md.Append(key, values...)
+		}
+	}
+	return md
+}
+
+// getRpcTimeout is the default deadline for the gRPC calls made for one HTTP
+// request. Zero means the calls last as long as the HTTP request does.
+func getRpcTimeout() (time.Duration, error) {
+	timeout := os.Getenv("RPC_TIMEOUT")
+	if timeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(timeout)
+}
+
+// getCallTimeout lets a Timeout header on the HTTP request override
+// RPC_TIMEOUT.
+func getCallTimeout(req *http.Request, defaultTimeout time.Duration) (time.Duration, error) {
+	timeout := req.Header.Get("Timeout")
+	if timeout == "" {
+		return defaultTimeout, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid Timeout header %q", timeout)
+	}
+	return d, nil
+}
+
+type callResultKey struct{}
+
+// callResult is filled in by the stats handlers as the gRPC calls for one
+// HTTP request are made: how many attempts they took, and the address of the
+// backend that answered the last one.
+type callResult struct {
+	attempts int32
+	mutex    sync.Mutex
+	backend  string
+}
+
+func withCallResult(ctx context.Context) (context.Context, *callResult) {
+	result := &callResult{}
+	return context.WithValue(ctx, callResultKey{}, result), result
+}
+
+func (r *callResult) setBackend(addr string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.backend = addr
+}
+
+func (r *callResult) getBackend() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.backend
+}
+
+// attemptCounter is a stats.Handler that counts every attempt the gRPC
+// client makes for a call, including the retries made by its service config.
+type attemptCounter struct{}
+
+func (attemptCounter) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (attemptCounter) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if begin, ok := s.(*stats.Begin); ok && begin.IsClient() {
+		if result, ok := ctx.Value(callResultKey{}).(*callResult); ok {
+			atomic.AddInt32(&result.attempts, 1)
+		}
+	}
+}
+
+func (attemptCounter) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (attemptCounter) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+// callResultWriter reports the number of gRPC attempts in the
+// X-Grpc-Attempts response header, and the backend that answered in
+// X-Backend-Address.
+type callResultWriter struct {
+	http.ResponseWriter
+	result      *callResult
+	wroteHeader bool
+}
+
+func (w *callResultWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.Header().Set("X-Grpc-Attempts", strconv.Itoa(int(atomic.LoadInt32(&w.result.attempts))))
+		if backend := w.result.getBackend(); backend != "" {
+			w.Header().Set("X-Backend-Address", backend)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *callResultWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *callResultWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// streamingPaths serve streaming calls, which RPC_TIMEOUT would cut short. As
+// with colorctl's -timeout, they only get a deadline when a Timeout header
+// asks for one.
+var streamingPaths = map[string]bool{
+	"/watchColor":   true,
+	"/reportColors": true,
+	"/colorChat":    true,
+	"/watchHealth":  true,
+}
+
+// withCallContext derives the context of the gRPC calls made for each HTTP
+// request from the request itself, so they are cancelled when the HTTP client
+// goes away. It adds the deadline, the forwarded headers and the call result.
+func withCallContext(h http.Handler, defaultTimeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rpcTimeout := defaultTimeout
+		if streamingPaths[req.URL.Path] {
+			rpcTimeout = 0
+		}
+		timeout, err := getCallTimeout(req, rpcTimeout)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		ctx = metadata.NewOutgoingContext(ctx, forwardedMetadata(req))
+		ctx, result := withCallResult(ctx)
+		h.ServeHTTP(&callResultWriter{ResponseWriter: w, result: result}, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	// registers RetryInfo, ErrorInfo and the other google.rpc details so they
+	// can be decoded from a status
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// rpcError is the JSON body written when a call to the color server fails.
+type rpcError struct {
+	Method  string            `json:"method,omitempty"`
+	Code    int               `json:"code"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+	Envoy   map[string]string `json:"envoy,omitempty"`
+	// Metadata is the rest of the headers and trailers of the call, such as
+	// the trailers the color server's flakiness adds.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// isEnvoyMetadata reports whether key is one envoyMetadata picks.
+func isEnvoyMetadata(key string) bool {
+	return strings.HasPrefix(key, "x-envoy-") || key == "server" || key == "grpc-retry-pushback-ms"
+}
+
+// otherMetadata picks the headers and trailers envoyMetadata doesn't, other
+// than the ones every gRPC response has.
+func otherMetadata(mds ...metadata.MD) map[string]string {
+	found := make(map[string]string)
+	for _, md := range mds {
+		for key, values := range md {
+			if len(values) == 0 || isEnvoyMetadata(key) || key == "content-type" || key == "date" {
+				continue
+			}
+			found[key] = strings.Join(values, ",")
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found
+}
+
+// envoyMetadata picks the headers and trailers that tell whether Envoy or the
+// color server produced a failure, and whether Envoy asked for a backoff.
+func envoyMetadata(mds ...metadata.MD) map[string]string {
+	found := make(map[string]string)
+	for _, md := range mds {
+		for key, values := range md {
+			if len(values) == 0 {
+				continue
+			}
+			if isEnvoyMetadata(key) {
+				found[key] = strings.Join(values, ",")
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found
+}
+
+func newRpcError(method string, err error, mds ...metadata.MD) *rpcError {
+	s := status.Convert(err)
+	e := &rpcError{
+		Method:   method,
+		Code:     int(s.Code()),
+		Status:   s.Code().String(),
+		Message:  s.Message(),
+		Envoy:    envoyMetadata(mds...),
+		Metadata: otherMetadata(mds...),
+	}
+	for _, detail := range s.Proto().GetDetails() {
+		b, err := protojson.Marshal(detail)
+		if err != nil {
+			// not a type we know, keep at least its name
+			b, _ = json.Marshal(map[string]string{"@type": detail.GetTypeUrl()})
+		}
+		e.Details = append(e.Details, b)
+	}
+	return e
+}
+
+// handleRpcError writes err as a JSON rpcError, with the HTTP status that
+// matches its gRPC code. mds are the header and trailer metadata of the call.
+func handleRpcError(method string, err error, w http.ResponseWriter, mds ...metadata.MD) {
+	e := newRpcError(method, err, mds...)
+	code := runtime.HTTPStatusFromCode(status.Code(err))
+	log.Printf("%s failed with %s (HTTP %d): %v", method, e.Status, code, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(e)
+}
+
+// gatewayErrorHandler makes the generated gateway report errors the same way
+// as the handlers written by hand.
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, req *http.Request, err error) {
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		method = req.URL.Path
+	}
+	md, _ := runtime.ServerMetadataFromContext(ctx)
+	handleRpcError(method, err, w, md.HeaderMD, md.TrailerMD)
+}
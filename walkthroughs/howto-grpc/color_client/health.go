@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// getHealthHandler calls Health.Check for the "service" query parameter,
+// the whole server when it is omitted.
+func getHealthHandler(h healthpb.HealthClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service := req.URL.Query().Get("service")
+		var header, trailer metadata.MD
+		resp, err := h.Check(req.Context(), &healthpb.HealthCheckRequest{Service: service}, grpc.Header(&header), grpc.Trailer(&trailer))
+		if err != nil {
+			handleRpcError("/grpc.health.v1.Health/Check", err, w, header, trailer)
+			return
+		}
+		log.Printf("Got Check response: %v", resp)
+		fmt.Fprint(w, resp.GetStatus().String())
+	}
+}
+
+// watchHealthHandler streams one line per serving status change for the
+// "service" query parameter until the client goes away.
+func watchHealthHandler(h healthpb.HealthClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service := req.URL.Query().Get("service")
+		stream, err := h.Watch(req.Context(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			handleRpcError("/grpc.health.v1.Health/Watch", err, w)
+			return
+		}
+		flusher, _ := w.(http.Flusher)
+		for seen := 0; ; seen++ {
+			resp, err := stream.Recv()
+			if err != nil {
+				if seen == 0 {
+					header, _ := stream.Header()
+					handleRpcError("/grpc.health.v1.Health/Watch", err, w, header, stream.Trailer())
+					return
+				}
+				log.Printf("Watch stream ended: %v", err)
+				return
+			}
+			log.Printf("Got Watch response: %v", resp)
+			fmt.Fprintln(w, resp.GetStatus().String())
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rpcLogger writes one JSON object per line, without the timestamp prefix of
+// the standard logger.
+var rpcLogger = log.New(os.Stdout, "", 0)
+
+// redactedMetadata are logged by name only.
+var redactedMetadata = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"x-api-key":     true,
+}
+
+// rpcLog is the structured log line written for every RPC.
+type rpcLog struct {
+	Time       string            `json:"time"`
+	Level      string            `json:"level"`
+	Msg        string            `json:"msg"`
+	Type       string            `json:"grpc.type"`
+	Service    string            `json:"grpc.service"`
+	Method     string            `json:"grpc.method"`
+	Code       string            `json:"grpc.code"`
+	Error      string            `json:"error,omitempty"`
+	DurationMs float64           `json:"duration_ms"`
+	Peer       string            `json:"peer.address,omitempty"`
+	Identity   string            `json:"peer.identity,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	SpanID     string            `json:"span_id,omitempty"`
+}
+
+// codeLevel picks the log level for a status code: failures caused by the
+// caller are info, failures that may be transient are warn.
+func codeLevel(code codes.Code) string {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return "info"
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return "warn"
+	}
+	return "error"
+}
+
+func logMetadata(md metadata.MD) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	logged := make(map[string]string, len(md))
+	for key, values := range md {
+		switch {
+		case strings.HasSuffix(key, "-bin"):
+			continue
+		case redactedMetadata[key]:
+			logged[key] = "REDACTED"
+		default:
+			logged[key] = strings.Join(values, ",")
+		}
+	}
+	return logged
+}
+
+func logRPC(ctx context.Context, rpcType, fullMethod string, start time.Time, p *peer.Peer, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err)
+	entry := rpcLog{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Level:      codeLevel(code),
+		Msg:        "finished call",
+		Type:       rpcType,
+		Service:    service,
+		Method:     method,
+		Code:       code.String(),
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+	if p.Addr != nil {
+		entry.Peer = p.Addr.String()
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			entry.Identity = peerIdentity(tlsInfo.State.PeerCertificates[0])
+		}
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	entry.Metadata = logMetadata(md)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry.TraceID = sc.TraceID().String()
+		entry.SpanID = sc.SpanID().String()
+	}
+	b, _ := json.Marshal(entry)
+	rpcLogger.Println(string(b))
+}
+
+func unaryLoggingInterceptor(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	var p peer.Peer
+	err := invoker(ctx, fullMethod, req, reply, cc, append(opts, grpc.Peer(&p))...)
+	logRPC(ctx, "unary", fullMethod, start, &p, err)
+	return err
+}
+
+func streamLoggingInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	// the peer is filled in when the stream finishes
+	p := &peer.Peer{}
+	stream, err := streamer(ctx, desc, cc, fullMethod, append(opts, grpc.Peer(p))...)
+	if err != nil {
+		logRPC(ctx, streamType(desc), fullMethod, start, p, err)
+		return nil, err
+	}
+	return &finishingClientStream{
+		ClientStream: stream,
+		desc:         desc,
+		onFinish: func(err error) {
+			logRPC(ctx, streamType(desc), fullMethod, start, p, err)
+		},
+	}, nil
+}
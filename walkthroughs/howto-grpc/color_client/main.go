@@ -2,29 +2,57 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_client/color"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	_ "google.golang.org/grpc/xds" // resolves xds:/// targets
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
-func handleRpcError(method string, err error, w http.ResponseWriter) {
-	s, _ := status.FromError(err)
-	if s.Code() != codes.Unimplemented {
-		http.Error(w, err.Error(), 500)
-		log.Printf("Something really bad happened: %v %v", s.Code(), err)
-		return
-	}
-	http.Error(w, err.Error(), 404)
-	log.Printf("Can't find %s method: %v %v", method, s.Code(), err)
+// shutdownTimeout is how long SIGTERM waits for requests in flight, within
+// the 30 seconds ECS gives a task to stop.
+const shutdownTimeout = 20 * time.Second
+
+// headerMatcher forwards the same headers as the handlers written by hand,
+// as well as the gateway's defaults.
+func headerMatcher(key string) (string, bool) {
+	if key := strings.ToLower(key); forwardHeaders[key] {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// newGateway serves every ColorService method as JSON over HTTP, using the
+// handlers generated from color.proto and color_http.yaml.
+func newGateway(ctx context.Context, conn *grpc.ClientConn) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+	if err := pb.RegisterColorServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	return mux, nil
 }
 
 func main() {
@@ -39,102 +67,116 @@ func main() {
 	log.Printf("COLOR_HOST is: %v", colorHost)
 	log.Printf("PORT is: %v", port)
 
-	// Connect to COLOR_HOST
-	conn, err := grpc.Dial(colorHost, grpc.WithInsecure())
+	rpcTimeout, err := getRpcTimeout()
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		log.Fatalf("invalid RPC_TIMEOUT: %v", err)
+	}
+	log.Printf("RPC_TIMEOUT is: %v", rpcTimeout)
+	lbPolicy, err := getLbPolicy()
+	if err != nil {
+		log.Fatalf("invalid load balancing config: %v", err)
+	}
+	target, targetOpts, err := getTarget(colorHost, lbPolicy)
+	if err != nil {
+		log.Fatalf("invalid load balancing config: %v", err)
+	}
+	serviceConfig, err := getServiceConfig(lbPolicy)
+	if err != nil {
+		log.Fatalf("invalid service config: %v", err)
+	}
+	creds, err := getClientCredentials(colorHost)
+	if err != nil {
+		log.Fatalf("failed to load TLS config: %v", err)
+	}
+	transportOpts, transport, err := getTransportOptions()
+	if err != nil {
+		log.Fatalf("failed to configure the transport: %v", err)
+	}
+	shutdownTracing, err := initTracing("color_client")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
 	}
-	defer conn.Close()
-	c := pb.NewColorServiceClient(conn)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	http.HandleFunc("/ping", func(w http.ResponseWriter, req *http.Request) {})
-
-	http.HandleFunc("/getColor", func(w http.ResponseWriter, req *http.Request) {
-		log.Printf("Recived getColor request: %v", req)
-		resp, err := c.GetColor(ctx, &pb.GetColorRequest{})
-		if err != nil {
-			handleRpcError("GetColor", err, w)
-			return
-		}
-		log.Printf("Got GetColor response: %v", resp)
-		fmt.Fprint(w, strings.ToLower(resp.GetColor().String()))
-	})
 
-	http.HandleFunc("/setColor", func(w http.ResponseWriter, req *http.Request) {
-		log.Printf("Recieved setColor request: %v", req)
-		defer req.Body.Close()
-		color, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			http.Error(w, err.Error(), 400)
-			log.Printf("Could not read request body: %v", err)
-			return
-		}
-		colorString := strings.ToUpper(string(color))
-		resp, err := c.SetColor(ctx, &pb.SetColorRequest{Color: pb.Color(pb.Color_value[colorString])})
-		if err != nil {
-			handleRpcError("SetColor", err, w)
-			return
-		}
-		log.Printf("Got SetColor response: %v", resp)
-		fmt.Fprint(w, strings.ToLower(resp.GetColor().String()))
-	})
+	monitor := &connMonitor{}
+	backends := newDistribution()
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithStatsHandler(attemptCounter{}),
+		grpc.WithStatsHandler(monitor),
+		grpc.WithStatsHandler(backends),
+		grpc.WithChainUnaryInterceptor(
+			otelgrpc.UnaryClientInterceptor(),
+			unaryMetricsInterceptor,
+			unaryLoggingInterceptor,
+		),
+		grpc.WithChainStreamInterceptor(
+			otelgrpc.StreamClientInterceptor(),
+			streamMetricsInterceptor,
+			streamLoggingInterceptor,
+		),
+	}
+	if creds != nil {
+		opts[0] = grpc.WithTransportCredentials(creds)
+	}
+	if serviceConfig != "" {
+		log.Printf("gRPC service config is: %v", serviceConfig)
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+	opts = append(opts, transportOpts...)
+	opts = append(opts, targetOpts...)
 
-	http.HandleFunc("/getFlakiness", func(w http.ResponseWriter, req *http.Request) {
-		log.Printf("Recived getFlakiness request: %v", req)
-		resp, err := c.GetFlakiness(ctx, &pb.GetFlakinessRequest{})
-		if err != nil {
-			handleRpcError("GetFlakiness", err, w)
-			return
-		}
-		log.Printf("Got GetFlakiness response: %v", resp)
-		fmt.Fprint(w, resp.String())
-	})
+	// Connect to COLOR_HOST, through the xDS management server named in
+	// GRPC_XDS_BOOTSTRAP when it is an xds:/// target
+	if strings.HasPrefix(colorHost, "xds:") {
+		log.Printf("Resolving COLOR_HOST through xDS, ignoring LB_POLICY and the retry config")
+	}
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	go monitor.watch(context.Background(), conn)
+	h := healthpb.NewHealthClient(conn)
 
-	http.HandleFunc("/setFlakiness", func(w http.ResponseWriter, req *http.Request) {
-		log.Printf("Recieved setFlakiness request: %v", req)
-		query := req.URL.Query()
-		rates, ok := query["rate"]
-		if !ok {
-			http.Error(w, "rate must be specified", 400)
-			log.Printf("Could not read rate parameter")
-			return
-		}
-		rate, err := strconv.ParseFloat(rates[0], 32)
-		if err != nil {
-			http.Error(w, err.Error(), 400)
-			log.Printf("Could not parse rate parameter: %v", err)
-			return
-		}
-		if rate < 0.0 || rate > 1.0 {
-			http.Error(w, "rate must be between 0.0 and 1.0", 400)
-			log.Printf("Invalid rate parameter: %v", rate)
-			return
-		}
+	gateway, err := newGateway(context.Background(), conn)
+	if err != nil {
+		log.Fatalf("failed to register gateway: %v", err)
+	}
 
-		qCodes, ok := query["code"]
-		if !ok {
-			http.Error(w, "code must be specified", 400)
-			log.Printf("Could not read code parameter: %v", err)
-			return
-		}
+	http.HandleFunc("/ping", func(w http.ResponseWriter, req *http.Request) {})
+	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/connection", connectionHandler(conn, monitor, transport))
+	http.Handle("/distribution", distributionHandler(backends))
+	http.Handle("/getHealth", withCallContext(getHealthHandler(h), rpcTimeout))
+	http.Handle("/watchHealth", withCallContext(watchHealthHandler(h), rpcTimeout))
+	http.Handle("/peer", withCallContext(peerHandler(pb.NewColorServiceClient(conn)), rpcTimeout))
+	http.Handle("/", withCallContext(gateway, rpcTimeout))
 
-		code, err := strconv.ParseInt(qCodes[0], 10, 32)
-		if err != nil {
-			http.Error(w, err.Error(), 400)
-			log.Printf("Could not parse code parameter: %v", err)
-			return
-		}
-		resp, err := c.SetFlakiness(ctx, &pb.SetFlakinessRequest{
-			Flakiness: &pb.Flakiness{Rate: float32(rate), Code: int32(code)},
-		})
-		if err != nil {
-			handleRpcError("SetFlakiness", err, w)
-			return
+	// log.Fatal skips deferred calls, so SIGTERM stops the server here and
+	// main flushes the buffered spans itself
+	server := &http.Server{Addr: "0.0.0.0:" + port}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		sig := <-signals
+		log.Printf("Received %v, stopping for up to %v", sig, shutdownTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		// streams such as /watchColor run until the timeout
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("requests still running after %v, stopping: %v", shutdownTimeout, err)
+			server.Close()
 		}
-		log.Printf("Got SetFlakiess response: %v", resp)
-		fmt.Fprint(w, resp.String())
-	})
-	log.Fatal(http.ListenAndServe("0.0.0.0:"+port, nil))
+	}()
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	<-stopped
+	conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// The metrics follow the names used by go-grpc-prometheus, so existing
+// dashboards work, and add the code to the latency histogram so that it
+// can be compared with Envoy's per status gRPC stats. A call is counted once,
+// however many attempts it took.
+var (
+	clientStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_started_total",
+		Help: "Total number of RPCs started on the client.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	clientHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Total number of RPCs completed by the client, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	clientHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Histogram of response latency of RPCs made by the client.",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	clientMsgReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_msg_received_total",
+		Help: "Total number of stream messages received from the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	clientMsgSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_msg_sent_total",
+		Help: "Total number of stream messages sent by the client.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+func init() {
+	prometheus.MustRegister(clientStarted, clientHandled, clientHandlingSeconds, clientMsgReceived, clientMsgSent)
+}
+
+// splitMethod splits /color.ColorService/GetColor into its service and
+// method names.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+func streamType(desc *grpc.StreamDesc) string {
+	switch {
+	case desc.ClientStreams && desc.ServerStreams:
+		return "bidi_stream"
+	case desc.ClientStreams:
+		return "client_stream"
+	case desc.ServerStreams:
+		return "server_stream"
+	}
+	return "unary"
+}
+
+// finishingClientStream reports the messages of a stream, and calls onFinish
+// once when the stream is over: when the server has sent its last message,
+// or when receiving fails.
+type finishingClientStream struct {
+	grpc.ClientStream
+	desc     *grpc.StreamDesc
+	once     sync.Once
+	onSend   func()
+	onRecv   func()
+	onFinish func(error)
+}
+
+func (s *finishingClientStream) finish(err error) {
+	s.once.Do(func() { s.onFinish(err) })
+}
+
+func (s *finishingClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil && s.onSend != nil {
+		s.onSend()
+	}
+	return err
+}
+
+func (s *finishingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	default:
+		if s.onRecv != nil {
+			s.onRecv()
+		}
+		// a client-streaming call is over once its only response arrives
+		if !s.desc.ServerStreams {
+			s.finish(nil)
+		}
+	}
+	return err
+}
+
+func observe(rpcType, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err).String()
+	clientHandled.WithLabelValues(rpcType, service, method, code).Inc()
+	clientHandlingSeconds.WithLabelValues(rpcType, service, method, code).Observe(time.Since(start).Seconds())
+}
+
+func unaryMetricsInterceptor(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	service, method := splitMethod(fullMethod)
+	clientStarted.WithLabelValues("unary", service, method).Inc()
+	start := time.Now()
+	err := invoker(ctx, fullMethod, req, reply, cc, opts...)
+	observe("unary", fullMethod, start, err)
+	return err
+}
+
+func streamMetricsInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	rpcType := streamType(desc)
+	service, method := splitMethod(fullMethod)
+	clientStarted.WithLabelValues(rpcType, service, method).Inc()
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, fullMethod, opts...)
+	if err != nil {
+		observe(rpcType, fullMethod, start, err)
+		return nil, err
+	}
+	return &finishingClientStream{
+		ClientStream: stream,
+		desc:         desc,
+		onSend:       clientMsgSent.WithLabelValues(rpcType, service, method).Inc,
+		onRecv:       clientMsgReceived.WithLabelValues(rpcType, service, method).Inc,
+		onFinish: func(err error) {
+			observe(rpcType, fullMethod, start, err)
+		},
+	}, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []map[string]string `json:"name"`
+	RetryPolicy retryPolicy         `json:"retryPolicy"`
+}
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig,omitempty"`
+	MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+}
+
+func getEnvDuration(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got %q", name, value)
+	}
+	return d, nil
+}
+
+// getRetryCodes reads the comma separated RETRY_ON. It takes gRPC code names
+// in either the service config form (UNAVAILABLE) or Envoy's (unavailable,
+// deadline-exceeded), so the same list can be used for the app and the mesh.
+func getRetryCodes() ([]string, error) {
+	retryOn := os.Getenv("RETRY_ON")
+	if retryOn == "" {
+		retryOn = "UNAVAILABLE"
+	}
+	var names []string
+	for _, name := range strings.Split(retryOn, ",") {
+		name = strings.ToUpper(strings.Replace(strings.TrimSpace(name), "-", "_", -1))
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil || code == codes.OK {
+			return nil, fmt.Errorf("invalid RETRY_ON code %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// getServiceConfig builds a gRPC service config that balances calls with
+// lbPolicy, when it is set, and retries ColorService calls when
+// RETRY_MAX_ATTEMPTS is 2 or more. It returns "" when neither is on, leaving
+// retries to the mesh.
+func getServiceConfig(lbPolicy string) (string, error) {
+	config := serviceConfig{}
+	if lbPolicy != "" {
+		config.LoadBalancingConfig = []map[string]struct{}{{lbPolicy: {}}}
+	}
+	policy, err := getRetryPolicy()
+	if err != nil {
+		return "", err
+	}
+	if policy != nil {
+		config.MethodConfig = []methodConfig{{
+			Name:        []map[string]string{{"service": "color.ColorService"}},
+			RetryPolicy: *policy,
+		}}
+	}
+	if config.LoadBalancingConfig == nil && config.MethodConfig == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(config)
+	return string(b), err
+}
+
+// getRetryPolicy returns nil when app-level retries are off.
+func getRetryPolicy() (*retryPolicy, error) {
+	value := os.Getenv("RETRY_MAX_ATTEMPTS")
+	if value == "" {
+		return nil, nil
+	}
+	maxAttempts, err := strconv.Atoi(value)
+	if err != nil || maxAttempts < 0 {
+		return nil, fmt.Errorf("RETRY_MAX_ATTEMPTS must be a number, got %q", value)
+	}
+	if maxAttempts < 2 {
+		return nil, nil
+	}
+	initialBackoff, err := getEnvDuration("RETRY_INITIAL_BACKOFF", 100*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	maxBackoff, err := getEnvDuration("RETRY_MAX_BACKOFF", time.Second)
+	if err != nil {
+		return nil, err
+	}
+	retryCodes, err := getRetryCodes()
+	if err != nil {
+		return nil, err
+	}
+	return &retryPolicy{
+		MaxAttempts:          maxAttempts,
+		InitialBackoff:       fmt.Sprintf("%gs", initialBackoff.Seconds()),
+		MaxBackoff:           fmt.Sprintf("%gs", maxBackoff.Seconds()),
+		BackoffMultiplier:    2,
+		RetryableStatusCodes: retryCodes,
+	}, nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_client/color"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// tlsFiles loads an optional client certificate and key and an optional CA
+// bundle, and loads them again whenever one of the files changes, so they can
+// be rotated without a restart. A failed reload keeps the files loaded before
+// it.
+type tlsFiles struct {
+	certFile, keyFile, caFile string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+func (f *tlsFiles) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{f.certFile, f.keyFile, f.caFile} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (f *tlsFiles) load() (*tls.Certificate, *x509.CertPool, error) {
+	var cert *tls.Certificate
+	if f.certFile != "" {
+		c, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		cert = &c
+	}
+	if f.caFile == "" {
+		return cert, nil, nil
+	}
+	pem, err := ioutil.ReadFile(f.caFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, nil, fmt.Errorf("no certificates found in %s", f.caFile)
+	}
+	return cert, pool, nil
+}
+
+func (f *tlsFiles) get() (*tls.Certificate, *x509.CertPool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	modTime, err := f.latestModTime()
+	if err == nil && !f.modTime.IsZero() && !modTime.After(f.modTime) {
+		return f.cert, f.pool, nil
+	}
+	if err == nil {
+		var cert *tls.Certificate
+		var pool *x509.CertPool
+		if cert, pool, err = f.load(); err == nil {
+			log.Printf("Loaded TLS files %s, %s, %s", f.certFile, f.keyFile, f.caFile)
+			f.cert, f.pool, f.modTime = cert, pool, modTime
+			return f.cert, f.pool, nil
+		}
+	}
+	if f.modTime.IsZero() {
+		return nil, nil, err
+	}
+	log.Printf("Keeping previous TLS files, failed to reload: %v", err)
+	return f.cert, f.pool, nil
+}
+
+// verifyServer checks the server's chain against the current CA bundle and
+// serverName. It stands in for Go's own verification, which can't pick up a
+// rotated bundle.
+func (f *tlsFiles) verifyServer(state tls.ConnectionState, serverName string) error {
+	_, pool, err := f.get()
+	if err != nil {
+		return err
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server sent no certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err = state.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// getClientCredentials dials COLOR_HOST over TLS when TLS_ENABLED is true or
+// any of TLS_CA, TLS_CERT or TLS_SERVER_NAME is set. The server is verified
+// against TLS_CA, or the system roots without it, and TLS_CERT and TLS_KEY
+// are presented for mTLS. The server must be named TLS_SERVER_NAME, or the
+// host of colorHost, or of the target it dialed when colorHost is a list. It
+// returns nil when TLS is off.
+func getClientCredentials(colorHost string) (credentials.TransportCredentials, error) {
+	files := &tlsFiles{
+		certFile: os.Getenv("TLS_CERT"),
+		keyFile:  os.Getenv("TLS_KEY"),
+		caFile:   os.Getenv("TLS_CA"),
+	}
+	serverName := os.Getenv("TLS_SERVER_NAME")
+	if os.Getenv("TLS_ENABLED") != "true" && files.caFile == "" && files.certFile == "" && serverName == "" {
+		return nil, nil
+	}
+	if (files.certFile == "") != (files.keyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT and TLS_KEY must be set together")
+	}
+	// fail at startup rather than on the first handshake
+	if _, _, err := files.get(); err != nil {
+		return nil, err
+	}
+	log.Printf("TLS is on, client certificate is presented: %v", files.certFile != "")
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := files.get()
+			if err != nil || cert == nil {
+				// no certificate, the server decides whether that's fine
+				return &tls.Certificate{}, err
+			}
+			return cert, nil
+		},
+	}
+	if files.caFile != "" {
+		if serverName == "" {
+			serverName = targetHost(colorHost)
+		}
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			if serverName == "" {
+				// each of a list of targets is verified against its own host
+				return files.verifyServer(state, state.ServerName)
+			}
+			return files.verifyServer(state, serverName)
+		}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// targetHost is the host colorHost names, e.g. color_server.howto-grpc.local
+// for dns:///color_server.howto-grpc.local:8080, or "" for a list of targets.
+func targetHost(colorHost string) string {
+	if strings.Contains(colorHost, ",") || strings.Contains(colorHost, "=") {
+		return ""
+	}
+	if i := strings.Index(colorHost, ":///"); i >= 0 {
+		colorHost = colorHost[i+len(":///"):]
+	}
+	if host, _, err := net.SplitHostPort(colorHost); err == nil {
+		return host
+	}
+	return colorHost
+}
+
+// peerInfo is what /peer reports about the Color Server connection.
+type peerInfo struct {
+	Address     string   `json:"address"`
+	Security    string   `json:"security"`
+	TLSVersion  string   `json:"tlsVersion,omitempty"`
+	CipherSuite string   `json:"cipherSuite,omitempty"`
+	ServerName  string   `json:"serverName,omitempty"`
+	ALPN        string   `json:"alpn,omitempty"`
+	Subject     string   `json:"subject,omitempty"`
+	Issuer      string   `json:"issuer,omitempty"`
+	DNSNames    []string `json:"dnsNames,omitempty"`
+	URIs        []string `json:"uris,omitempty"`
+	NotAfter    string   `json:"notAfter,omitempty"`
+}
+
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// peerIdentity describes a certificate for the logs, preferring the SPIFFE
+// ID or DNS name over the subject.
+func peerIdentity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		return uri.String()
+	}
+	for _, name := range cert.DNSNames {
+		return name
+	}
+	return cert.Subject.String()
+}
+
+func newPeerInfo(p *peer.Peer) *peerInfo {
+	info := &peerInfo{Security: "insecure"}
+	if p.Addr != nil {
+		info.Address = p.Addr.String()
+	}
+	if p.AuthInfo != nil {
+		info.Security = p.AuthInfo.AuthType()
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return info
+	}
+	state := tlsInfo.State
+	info.TLSVersion = tlsVersions[state.Version]
+	info.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	info.ServerName = state.ServerName
+	info.ALPN = state.NegotiatedProtocol
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		info.Subject = cert.Subject.String()
+		info.Issuer = cert.Issuer.String()
+		info.DNSNames = cert.DNSNames
+		for _, uri := range cert.URIs {
+			info.URIs = append(info.URIs, uri.String())
+		}
+		info.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+	return info
+}
+
+// peerHandler calls GetColor and reports who answered it and how the
+// connection was secured.
+func peerHandler(c pb.ColorServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var p peer.Peer
+		if _, err := c.GetColor(req.Context(), &pb.GetColorRequest{}, grpc.Peer(&p)); err != nil {
+			handleRpcError("/color.ColorService/GetColor", err, w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newPeerInfo(&p))
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// getDuration reads an optional duration from name, e.g. KEEPALIVE_TIME=30s.
+func getDuration(name string) (time.Duration, bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	log.Printf("%s is: %v", name, d)
+	return d, true, nil
+}
+
+// getSize reads an optional message size in bytes from name.
+func getSize(name string) (int, bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, false, fmt.Errorf("invalid %s %q, it must be a number of bytes", name, value)
+	}
+	log.Printf("%s is: %v", name, n)
+	return n, true, nil
+}
+
+func getBool(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	log.Printf("%s is: %v", name, b)
+	return b, nil
+}
+
+// transportSettings are the keepalive, message size and compression settings
+// the client dials with, as reported by /connection. Empty ones are gRPC's
+// defaults: no keepalive pings, 4MB received messages at most, and no limit
+// or compression on sent ones.
+type transportSettings struct {
+	KeepaliveTime                string `json:"keepalive_time,omitempty"`
+	KeepaliveTimeout             string `json:"keepalive_timeout,omitempty"`
+	KeepalivePermitWithoutStream bool   `json:"keepalive_permit_without_stream"`
+	MaxRecvMsgSize               int    `json:"max_recv_msg_size,omitempty"`
+	MaxSendMsgSize               int    `json:"max_send_msg_size,omitempty"`
+	Compression                  string `json:"compression,omitempty"`
+}
+
+// getTransportOptions configures keepalive, message size limits and
+// compression from the environment.
+func getTransportOptions() ([]grpc.DialOption, transportSettings, error) {
+	var opts []grpc.DialOption
+	var settings transportSettings
+	var params keepalive.ClientParameters
+	keepaliveTime, setTime, err := getDuration("KEEPALIVE_TIME")
+	if err != nil {
+		return nil, settings, err
+	}
+	keepaliveTimeout, setTimeout, err := getDuration("KEEPALIVE_TIMEOUT")
+	if err != nil {
+		return nil, settings, err
+	}
+	permit, err := getBool("KEEPALIVE_PERMIT_WITHOUT_STREAM")
+	if err != nil {
+		return nil, settings, err
+	}
+	if setTime || setTimeout || permit {
+		params.Time = keepaliveTime
+		params.Timeout = keepaliveTimeout
+		params.PermitWithoutStream = permit
+		if !setTime {
+			// keep gRPC's default of no pings
+			params.Time = time.Duration(1<<63 - 1)
+		} else {
+			settings.KeepaliveTime = keepaliveTime.String()
+		}
+		if setTimeout {
+			settings.KeepaliveTimeout = keepaliveTimeout.String()
+		}
+		settings.KeepalivePermitWithoutStream = permit
+		opts = append(opts, grpc.WithKeepaliveParams(params))
+	}
+
+	var callOpts []grpc.CallOption
+	if n, ok, err := getSize("MAX_RECV_MSG_SIZE"); err != nil {
+		return nil, settings, err
+	} else if ok {
+		settings.MaxRecvMsgSize = n
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(n))
+	}
+	if n, ok, err := getSize("MAX_SEND_MSG_SIZE"); err != nil {
+		return nil, settings, err
+	} else if ok {
+		settings.MaxSendMsgSize = n
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(n))
+	}
+	switch compression := os.Getenv("COMPRESSION"); compression {
+	case "", "identity":
+	case gzip.Name:
+		log.Printf("COMPRESSION is: %v", compression)
+		settings.Compression = compression
+		callOpts = append(callOpts, grpc.UseCompressor(compression))
+	default:
+		return nil, settings, fmt.Errorf("invalid COMPRESSION %q, it must be gzip or identity", compression)
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts, settings, nil
+}
+
+type stateChange struct {
+	State string    `json:"state"`
+	Time  time.Time `json:"time"`
+}
+
+// callInfo describes what was negotiated for one gRPC call, as seen on the
+// wire. Wire sizes include gRPC's 5 byte message prefix and compression.
+type callInfo struct {
+	Method              string `json:"method"`
+	Code                string `json:"code"`
+	RemoteAddress       string `json:"remote_address,omitempty"`
+	RequestCompression  string `json:"request_compression,omitempty"`
+	ResponseCompression string `json:"response_compression,omitempty"`
+	SentBytes           int    `json:"sent_bytes"`
+	SentWireBytes       int    `json:"sent_wire_bytes"`
+	ReceivedBytes       int    `json:"received_bytes"`
+	ReceivedWireBytes   int    `json:"received_wire_bytes"`
+}
+
+// maxStateChanges is how many connectivity state changes /connection shows.
+const maxStateChanges = 20
+
+// connMonitor records the connectivity state changes of the client's
+// connection, and is a stats.Handler that records the last call made on it.
+// Together they show when the mesh or the server drops idle connections, and
+// which side rejects large or compressed messages.
+type connMonitor struct {
+	mutex        sync.Mutex
+	changes      []stateChange
+	transports   int
+	lastCall     *callInfo
+	lastCallTime time.Time
+}
+
+// watch logs every state change of conn until ctx is done.
+func (m *connMonitor) watch(ctx context.Context, conn *grpc.ClientConn) {
+	for {
+		state := conn.GetState()
+		log.Printf("Connection to %s is %s", conn.Target(), state)
+		m.mutex.Lock()
+		m.changes = append(m.changes, stateChange{State: state.String(), Time: time.Now()})
+		if len(m.changes) > maxStateChanges {
+			m.changes = m.changes[1:]
+		}
+		m.mutex.Unlock()
+		if state == connectivity.Shutdown || !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
+
+type callInfoKey struct{}
+
+func (m *connMonitor) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, &callInfo{Method: info.FullMethodName})
+}
+
+func (m *connMonitor) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	call, ok := ctx.Value(callInfoKey{}).(*callInfo)
+	if !ok || !s.IsClient() {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.OutHeader:
+		call.RequestCompression = s.Compression
+		if s.RemoteAddr != nil {
+			call.RemoteAddress = s.RemoteAddr.String()
+		}
+	case *stats.InHeader:
+		call.ResponseCompression = s.Compression
+	case *stats.OutPayload:
+		call.SentBytes += s.Length
+		call.SentWireBytes += s.WireLength
+	case *stats.InPayload:
+		call.ReceivedBytes += s.Length
+		call.ReceivedWireBytes += s.WireLength
+	case *stats.End:
+		call.Code = status.Code(s.Error).String()
+		m.mutex.Lock()
+		m.lastCall = call
+		m.lastCallTime = s.EndTime
+		m.mutex.Unlock()
+	}
+}
+
+func (m *connMonitor) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (m *connMonitor) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnBegin); ok {
+		m.mutex.Lock()
+		m.transports++
+		m.mutex.Unlock()
+	}
+}
+
+type connectionInfo struct {
+	Target       string            `json:"target"`
+	State        string            `json:"state"`
+	StateChanges []stateChange     `json:"state_changes"`
+	Transports   int               `json:"transports_opened"`
+	Settings     transportSettings `json:"settings"`
+	LastCall     *callInfo         `json:"last_call,omitempty"`
+	LastCallAgo  string            `json:"last_call_ago,omitempty"`
+}
+
+// connectionHandler reports the state of the connection to COLOR_HOST, the
+// settings it was dialed with and what was negotiated for the last call.
+func connectionHandler(conn *grpc.ClientConn, m *connMonitor, settings transportSettings) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		m.mutex.Lock()
+		info := connectionInfo{
+			Target:       conn.Target(),
+			State:        conn.GetState().String(),
+			StateChanges: append([]stateChange(nil), m.changes...),
+			Transports:   m.transports,
+			Settings:     settings,
+			LastCall:     m.lastCall,
+		}
+		if m.lastCall != nil {
+			info.LastCallAgo = time.Since(m.lastCallTime).Round(time.Millisecond).String()
+		}
+		m.mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(info)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/admin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// registerStandardServices registers health next to ColorService, and the
+// debug services when asked to: server reflection when GRPC_REFLECTION is
+// true, and channelz, which grpcdebug reads, when GRPC_ADMIN is true. CSDS is
+// left out, as nothing here gets its config from xDS. The returned function
+// frees what the admin services hold.
+func registerStandardServices(s *grpc.Server, health healthpb.HealthServer) (func(), error) {
+	healthpb.RegisterHealthServer(s, health)
+
+	reflectionOn, err := getBool("GRPC_REFLECTION")
+	if err != nil {
+		return nil, err
+	}
+	if reflectionOn {
+		reflection.Register(s)
+	}
+	adminOn, err := getBool("GRPC_ADMIN")
+	if err != nil {
+		return nil, err
+	}
+	if !adminOn {
+		return func() {}, nil
+	}
+	return admin.Register(s)
+}
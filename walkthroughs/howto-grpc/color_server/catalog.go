@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// enumHexes are the colors of the Color enum, which every catalog has.
+var enumHexes = map[pb.Color]string{
+	pb.Color_RED:    "#ff0000",
+	pb.Color_BLUE:   "#0000ff",
+	pb.Color_GREEN:  "#008000",
+	pb.Color_YELLOW: "#ffff00",
+	pb.Color_ORANGE: "#ffa500",
+	pb.Color_PURPLE: "#800080",
+	pb.Color_PINK:   "#ffc0cb",
+	pb.Color_BLACK:  "#000000",
+	pb.Color_WHITE:  "#ffffff",
+}
+
+var (
+	colorName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	colorHex  = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+)
+
+// catalog is the colors the server can be set to, by lowercase name, and the
+// labels it adds to every ColorInfo.
+type catalog struct {
+	hexes  map[string]string
+	labels map[string]string
+}
+
+// parsePairs parses a comma separated list of name=value pairs.
+func parsePairs(env, list string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(list, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, it must look like name=value", env, pair)
+		}
+		pairs[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return pairs, nil
+}
+
+// loadCatalog adds the colors of COLOR_CATALOG, e.g. teal=#008080,navy=#000080,
+// to those of the Color enum, and reads the labels for every response from
+// COLOR_LABELS, e.g. version=v2,zone=us-west-2a.
+func loadCatalog() (*catalog, error) {
+	c := &catalog{hexes: make(map[string]string)}
+	for color, hex := range enumHexes {
+		c.hexes[strings.ToLower(color.String())] = hex
+	}
+	extra, err := parsePairs("COLOR_CATALOG", os.Getenv("COLOR_CATALOG"))
+	if err != nil {
+		return nil, err
+	}
+	for name, hex := range extra {
+		name, hex = strings.ToLower(name), strings.ToLower(hex)
+		if !colorName.MatchString(name) {
+			return nil, fmt.Errorf("invalid COLOR_CATALOG name %q, it must be letters, digits, - and _", name)
+		}
+		if !colorHex.MatchString(hex) {
+			return nil, fmt.Errorf("invalid COLOR_CATALOG hex %q for %s, it must look like #008080", hex, name)
+		}
+		if _, ok := c.hexes[name]; ok {
+			return nil, fmt.Errorf("COLOR_CATALOG color %s is already in the catalog", name)
+		}
+		c.hexes[name] = hex
+	}
+	if c.labels, err = parsePairs("COLOR_LABELS", os.Getenv("COLOR_LABELS")); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *catalog) names() []string {
+	var names []string
+	for name := range c.hexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup finds a color by name, whatever its case.
+func (c *catalog) lookup(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	_, ok := c.hexes[name]
+	return name, ok
+}
+
+// info describes a color of the catalog, or nothing while there is no color.
+func (c *catalog) info(name string) *pb.ColorInfo {
+	if name == "" {
+		return nil
+	}
+	labels := make(map[string]string, len(c.labels))
+	for k, v := range c.labels {
+		labels[k] = v
+	}
+	return &pb.ColorInfo{Name: name, Hex: c.hexes[name], Labels: labels}
+}
+
+// enumOf is the Color of a catalog color, NO_COLOR for those beyond the enum.
+func enumOf(name string) pb.Color {
+	return pb.Color(pb.Color_value[strings.ToUpper(name)])
+}
+
+// invalidColor fails a SetColor with the field at fault.
+func invalidColor(field, description string) error {
+	s, err := status.New(codes.InvalidArgument, description).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, description)
+	}
+	return s.Err()
+}
+
+// resolve finds the catalog color a SetColor asks for, by name or by enum.
+// When both are given they must agree. NO_COLOR, or no color at all, clears
+// the color, which resolves to "".
+func (c *catalog) resolve(in *pb.SetColorRequest) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(in.Name), pb.Color_NO_COLOR.String()) {
+		if in.Color != pb.Color_NO_COLOR {
+			return "", invalidColor("color", fmt.Sprintf("color %v and name %q are different colors", in.Color, in.Name))
+		}
+		return "", nil
+	}
+	if in.Name != "" {
+		name, ok := c.lookup(in.Name)
+		if !ok {
+			return "", invalidColor("name", fmt.Sprintf("unknown color %q, the catalog has: %s", in.Name, strings.Join(c.names(), ", ")))
+		}
+		if in.Color != pb.Color_NO_COLOR && in.Color != enumOf(name) {
+			return "", invalidColor("color", fmt.Sprintf("color %v and name %q are different colors", in.Color, in.Name))
+		}
+		return name, nil
+	}
+	if _, ok := pb.Color_name[int32(in.Color)]; !ok {
+		return "", invalidColor("color", fmt.Sprintf("unknown color %v, set a color or a name from the catalog", in.Color))
+	}
+	if in.Color == pb.Color_NO_COLOR {
+		return "", nil
+	}
+	return strings.ToLower(in.Color.String()), nil
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const getColorMethod = "/color.ColorService/GetColor"
+
+// setFlakinessMethod is never targeted, so that faults can always be undone.
+const setFlakinessMethod = "/color.ColorService/SetFlakiness"
+
+// errorDomain is the ErrorInfo domain when the flakiness doesn't give one.
+const errorDomain = "color.howto-grpc.local"
+
+// faults applies a Flakiness configuration and keeps the state its
+// deterministic triggers need.
+type faults struct {
+	config  *pb.Flakiness
+	setAt   time.Time
+	calls   uint64
+	methods map[string]bool
+}
+
+func newFaults(config *pb.Flakiness) *faults {
+	if config == nil {
+		config = &pb.Flakiness{}
+	}
+	f := &faults{config: config, setAt: time.Now(), methods: make(map[string]bool)}
+	for _, m := range config.Methods {
+		f.methods[m] = true
+	}
+	return f
+}
+
+// validateFlakiness rejects configurations that can't be applied.
+func validateFlakiness(config *pb.Flakiness) error {
+	if config == nil {
+		return nil
+	}
+	if config.Rate < 0 || config.Rate > 1 {
+		return fmt.Errorf("rate must be between 0.0 and 1.0")
+	}
+	if config.Code < 0 || config.Code > int32(codes.Unauthenticated) {
+		return fmt.Errorf("code %d is not a gRPC status code", config.Code)
+	}
+	if config.BurstMs > 0 && config.BurstPeriodMs <= config.BurstMs {
+		return fmt.Errorf("burst_period_ms must be greater than burst_ms")
+	}
+	if config.RetryPushback && config.RetryDelayMs == 0 {
+		return fmt.Errorf("retry_pushback needs retry_delay_ms")
+	}
+	if config.ErrorDomain != "" && config.ErrorReason == "" {
+		return fmt.Errorf("error_domain needs error_reason")
+	}
+	for _, v := range config.QuotaViolations {
+		if strings.TrimSpace(strings.SplitN(v, ":", 2)[0]) == "" {
+			return fmt.Errorf("quota violation %q has no subject", v)
+		}
+	}
+	for _, t := range config.Trailers {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("trailer %q must look like key=value", t)
+		}
+		if strings.HasPrefix(strings.ToLower(kv[0]), "grpc-") {
+			return fmt.Errorf("trailer %q is reserved by gRPC, use retry_pushback for grpc-retry-pushback-ms", kv[0])
+		}
+	}
+	return nil
+}
+
+// targets reports whether fullMethod, e.g. /color.ColorService/GetColor, is
+// subject to faults.
+func (f *faults) targets(fullMethod string) bool {
+	if fullMethod == setFlakinessMethod {
+		return false
+	}
+	if len(f.methods) == 0 {
+		return fullMethod == getColorMethod
+	}
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return f.methods[fullMethod] || f.methods[name]
+}
+
+func (f *faults) latency() time.Duration {
+	latency := time.Duration(f.config.LatencyMs) * time.Millisecond
+	if f.config.LatencyJitterMs > 0 {
+		latency += time.Duration(rand.Int63n(int64(f.config.LatencyJitterMs)+1)) * time.Millisecond
+	}
+	return latency
+}
+
+// trigger returns why the nth targeted call should fail, or "" if it should not.
+func (f *faults) trigger(ctx context.Context, n uint64) string {
+	if key := f.config.MetadataKey; key != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get(key) {
+			if f.config.MetadataValue == "" || v == f.config.MetadataValue {
+				return "metadata " + key
+			}
+		}
+	}
+	if every := uint64(f.config.EveryNth); every > 0 && n%every == 0 {
+		return fmt.Sprintf("call %d of every %d", n, every)
+	}
+	if f.config.BurstMs > 0 {
+		period := time.Duration(f.config.BurstPeriodMs) * time.Millisecond
+		into := time.Since(f.setAt) % period
+		if into < time.Duration(f.config.BurstMs)*time.Millisecond {
+			return "burst window"
+		}
+	}
+	if rand.Float32() < f.config.Rate {
+		return "random"
+	}
+	return ""
+}
+
+// details are the google.rpc error details the flakiness attaches to a
+// failure of fullMethod, triggered by reason.
+func (f *faults) details(fullMethod, reason string) []protoiface.MessageV1 {
+	var details []protoiface.MessageV1
+	if f.config.RetryDelayMs > 0 {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Duration(f.config.RetryDelayMs) * time.Millisecond),
+		})
+	}
+	if f.config.ErrorReason != "" {
+		domain := f.config.ErrorDomain
+		if domain == "" {
+			domain = errorDomain
+		}
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   f.config.ErrorReason,
+			Domain:   domain,
+			Metadata: map[string]string{"method": fullMethod, "trigger": reason},
+		})
+	}
+	if len(f.config.QuotaViolations) > 0 {
+		quota := &errdetails.QuotaFailure{}
+		for _, v := range f.config.QuotaViolations {
+			kv := strings.SplitN(v, ":", 2)
+			violation := &errdetails.QuotaFailure_Violation{Subject: strings.TrimSpace(kv[0])}
+			if len(kv) == 2 {
+				violation.Description = strings.TrimSpace(kv[1])
+			}
+			quota.Violations = append(quota.Violations, violation)
+		}
+		details = append(details, quota)
+	}
+	return details
+}
+
+// trailers are the trailers the flakiness adds to failures.
+func (f *faults) trailers() metadata.MD {
+	md := metadata.MD{}
+	for _, t := range f.config.Trailers {
+		kv := strings.SplitN(t, "=", 2)
+		md.Append(kv[0], kv[1])
+	}
+	if f.config.RetryPushback {
+		md.Set("grpc-retry-pushback-ms", strconv.FormatUint(uint64(f.config.RetryDelayMs), 10))
+	}
+	return md
+}
+
+// inject delays a targeted call and returns the error to fail it with, if
+// any, and the trailers to send with that error.
+func (f *faults) inject(ctx context.Context, fullMethod string) (metadata.MD, error) {
+	if !f.targets(fullMethod) {
+		return nil, nil
+	}
+	n := atomic.AddUint64(&f.calls, 1)
+	if latency := f.latency(); latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	reason := f.trigger(ctx, n)
+	if reason == "" {
+		return nil, nil
+	}
+	code := codes.Code(f.config.Code)
+	if code == codes.OK {
+		code = codes.Internal
+	}
+	log.Printf("Failing %s with %v: %s", fullMethod, code, reason)
+	s := status.New(code, code.String())
+	if details := f.details(fullMethod, reason); len(details) > 0 {
+		var err error
+		if s, err = s.WithDetails(details...); err != nil {
+			log.Printf("Failed to attach error details: %v", err)
+			s = status.New(code, code.String())
+		}
+	}
+	return f.trailers(), s.Err()
+}
+
+func (s *colorServer) unaryFaultInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	f, _ := s.getFaults()
+	if trailers, err := f.inject(ctx, info.FullMethod); err != nil {
+		grpc.SetTrailer(ctx, trailers)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *colorServer) streamFaultInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	f, _ := s.getFaults()
+	if trailers, err := f.inject(ss.Context(), info.FullMethod); err != nil {
+		ss.SetTrailer(trailers)
+		return err
+	}
+	return handler(srv, ss)
+}
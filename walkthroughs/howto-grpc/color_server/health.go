@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const colorServiceName = "color.ColorService"
+
+// healthServer implements the gRPC Health Checking Protocol, including Watch.
+// Statuses start as SERVING and can be changed with ColorService.SetHealth.
+type healthServer struct {
+	*health.Server
+}
+
+func newHealthServer() *healthServer {
+	h := &healthServer{Server: health.NewServer()}
+	h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	h.SetServingStatus(colorServiceName, healthpb.HealthCheckResponse_SERVING)
+	return h
+}
+
+// servingStatus returns what Check currently reports for service.
+func (h *healthServer) servingStatus(ctx context.Context, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := h.Server.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	return resp.Status
+}
+
+func (s *colorServer) SetHealth(ctx context.Context, in *pb.SetHealthRequest) (*pb.SetHealthResponse, error) {
+	if _, ok := healthpb.HealthCheckResponse_ServingStatus_name[int32(in.Status)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown serving status %d", in.Status)
+	}
+	oldStatus := s.health.servingStatus(ctx, in.Service)
+	s.health.SetServingStatus(in.Service, healthpb.HealthCheckResponse_ServingStatus(in.Status))
+	return &pb.SetHealthResponse{Status: pb.ServingStatus(oldStatus)}, nil
+}
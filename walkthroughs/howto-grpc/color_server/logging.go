@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rpcLogger writes one JSON object per line, without the timestamp prefix of
+// the standard logger.
+var rpcLogger = log.New(os.Stdout, "", 0)
+
+// redactedMetadata are logged by name only.
+var redactedMetadata = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"x-api-key":     true,
+}
+
+// rpcLog is the structured log line written for every RPC.
+type rpcLog struct {
+	Time       string            `json:"time"`
+	Level      string            `json:"level"`
+	Msg        string            `json:"msg"`
+	Type       string            `json:"grpc.type"`
+	Service    string            `json:"grpc.service"`
+	Method     string            `json:"grpc.method"`
+	Code       string            `json:"grpc.code"`
+	Error      string            `json:"error,omitempty"`
+	DurationMs float64           `json:"duration_ms"`
+	Peer       string            `json:"peer.address,omitempty"`
+	Identity   string            `json:"peer.identity,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	SpanID     string            `json:"span_id,omitempty"`
+}
+
+// codeLevel picks the log level for a status code: failures caused by the
+// caller are info, failures that may be transient are warn.
+func codeLevel(code codes.Code) string {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return "info"
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return "warn"
+	}
+	return "error"
+}
+
+func logMetadata(md metadata.MD) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	logged := make(map[string]string, len(md))
+	for key, values := range md {
+		switch {
+		case strings.HasSuffix(key, "-bin"):
+			continue
+		case redactedMetadata[key]:
+			logged[key] = "REDACTED"
+		default:
+			logged[key] = strings.Join(values, ",")
+		}
+	}
+	return logged
+}
+
+func logRPC(ctx context.Context, rpcType, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err)
+	entry := rpcLog{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Level:      codeLevel(code),
+		Msg:        "finished call",
+		Type:       rpcType,
+		Service:    service,
+		Method:     method,
+		Code:       code.String(),
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.Peer = p.Addr.String()
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			entry.Identity = peerIdentity(tlsInfo.State.PeerCertificates[0])
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	entry.Metadata = logMetadata(md)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry.TraceID = sc.TraceID().String()
+		entry.SpanID = sc.SpanID().String()
+	}
+	b, _ := json.Marshal(entry)
+	rpcLogger.Println(string(b))
+}
+
+func unaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logRPC(ctx, "unary", info.FullMethod, start, err)
+	return resp, err
+}
+
+func streamLoggingInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logRPC(ss.Context(), streamType(info), info.FullMethod, start, err)
+	return err
+}
@@ -3,60 +3,79 @@ package main
 import (
 	"context"
 	"log"
-	"math/rand"
 	"net"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
+// shutdownTimeout is how long SIGTERM waits for calls in flight, within the
+// 30 seconds ECS gives a task to stop.
+const shutdownTimeout = 20 * time.Second
+
+// colorServer holds the color and flakiness behind mutex. Each has its own
+// version, so callers can change them with compare-and-set. The color is the
+// name of a color of the catalog.
 type colorServer struct {
-	color     pb.Color
-	flakiness pb.Flakiness
+	pb.UnimplementedColorServiceServer
+	catalog          *catalog
+	mutex            sync.RWMutex
+	color            string
+	colorVersion     uint64
+	faults           *faults
+	flakinessVersion uint64
+	watchers         colorWatchers
+	health           *healthServer
 }
 
 func (s *colorServer) GetColor(ctx context.Context, in *pb.GetColorRequest) (*pb.GetColorResponse, error) {
-	log.Printf("Received GetColor request")
-	// test for random flakiness in the api
-	if rand.Float32() < s.flakiness.Rate {
-		code := codes.Code(s.flakiness.Code)
-		return nil, status.Error(code, code.String())
-	}
-	return &pb.GetColorResponse{Color: s.color}, nil
+	color, version := s.getColor()
+	return &pb.GetColorResponse{Color: enumOf(color), Version: version, Info: s.catalog.info(color)}, nil
 }
 
 func (s *colorServer) SetColor(ctx context.Context, in *pb.SetColorRequest) (*pb.SetColorResponse, error) {
-	log.Printf("Received SetColor request: %v", in)
-	oldColor := s.color
-	s.color = in.Color
-	return &pb.SetColorResponse{Color: oldColor}, nil
-}
-
-func (s *colorServer) GetFlakiness(ctx context.Context, in *pb.GetFlakinessRequest) (*pb.GetFlakinessResponse, error) {
-	log.Printf("Received GetFlakiness request")
-	return &pb.GetFlakinessResponse{Flakiness: &s.flakiness}, nil
+	color, err := s.catalog.resolve(in)
+	if err != nil {
+		return nil, err
+	}
+	oldColor, version, err := s.setColor(color, in.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetColorResponse{Color: enumOf(oldColor), Version: version, Info: s.catalog.info(oldColor)}, nil
 }
 
-func (s *colorServer) SetFlakiness(ctx context.Context, in *pb.SetFlakinessRequest) (*pb.SetFlakinessResponse, error) {
-	log.Printf("Received SetFlakiness request: %v", in)
-	oldFlakiness := s.flakiness
-	s.flakiness = *in.Flakiness
-	return &pb.SetFlakinessResponse{Flakiness: &oldFlakiness}, nil
+func (s *colorServer) ListColors(ctx context.Context, in *pb.ListColorsRequest) (*pb.ListColorsResponse, error) {
+	resp := &pb.ListColorsResponse{}
+	for _, name := range s.catalog.names() {
+		resp.Colors = append(resp.Colors, s.catalog.info(name))
+	}
+	return resp, nil
 }
 
-func (s *colorServer) Check(ctx context.Context, in *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
-	log.Printf("Received Check request: %v", in)
-	return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+func (s *colorServer) GetFlakiness(ctx context.Context, in *pb.GetFlakinessRequest) (*pb.GetFlakinessResponse, error) {
+	f, version := s.getFaults()
+	return &pb.GetFlakinessResponse{Flakiness: f.config, Version: version}, nil
 }
 
-func (s *colorServer) Watch(in *health.HealthCheckRequest, _ health.Health_WatchServer) error {
-	log.Printf("Received Watch request: %v", in)
-	return status.Error(codes.Unimplemented, "unimplemented")
+func (s *colorServer) SetFlakiness(ctx context.Context, in *pb.SetFlakinessRequest) (*pb.SetFlakinessResponse, error) {
+	if err := validateFlakiness(in.Flakiness); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	oldFlakiness, version, err := s.setFaults(in.Flakiness, in.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetFlakinessResponse{Flakiness: oldFlakiness, Version: version}, nil
 }
 
 func main() {
@@ -74,12 +93,105 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	colorValue := pb.Color(pb.Color_value[strings.ToUpper(color)])
-	c := colorServer{color: colorValue}
+	catalog, err := loadCatalog()
+	if err != nil {
+		log.Fatalf("failed to load the color catalog: %v", err)
+	}
+	// NO_COLOR starts the server without a color until one is set
+	colorName, ok := catalog.lookup(color)
+	if strings.EqualFold(color, pb.Color_NO_COLOR.String()) {
+		colorName = ""
+	} else if !ok {
+		log.Fatalf("unknown COLOR %q, the catalog has: %s", color, strings.Join(catalog.names(), ", "))
+	}
+	log.Printf("COLOR_LABELS are: %v", catalog.labels)
+	c := colorServer{
+		catalog:          catalog,
+		color:            colorName,
+		colorVersion:     1,
+		faults:           newFaults(nil),
+		flakinessVersion: 1,
+		health:           newHealthServer(),
+	}
+	shutdownTracing, err := initTracing("color_server")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	serveMetrics()
+
+	// Faults are injected last, so the failures and latency they add are
+	// traced, counted and logged like any other.
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			otelgrpc.UnaryServerInterceptor(),
+			unaryMetricsInterceptor,
+			unaryLoggingInterceptor,
+			unaryCompressionInterceptor,
+			c.unaryFaultInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			streamMetricsInterceptor,
+			streamLoggingInterceptor,
+			streamCompressionInterceptor,
+			c.streamFaultInterceptor,
+		),
+	}
+	transportOpts, err := getTransportOptions()
+	if err != nil {
+		log.Fatalf("failed to configure the transport: %v", err)
+	}
+	opts = append(opts, transportOpts...)
+	creds, err := getServerCredentials()
+	if err != nil {
+		log.Fatalf("failed to load TLS config: %v", err)
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	s := grpc.NewServer(opts...)
 	pb.RegisterColorServiceServer(s, &c)
-	health.RegisterHealthServer(s, &c)
+	cleanup, err := registerStandardServices(s, c.health)
+	if err != nil {
+		log.Fatalf("failed to register services: %v", err)
+	}
+
+	// log.Fatalf skips deferred calls, so SIGTERM stops the server here and
+	// main flushes the buffered spans itself once Serve returns
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		sig := <-signals
+		log.Printf("Received %v, stopping for up to %v", sig, shutdownTimeout)
+		c.health.Shutdown()
+		gracefulStop(s, shutdownTimeout)
+	}()
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+	<-stopped
+	cleanup()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
+}
+
+// gracefulStop lets the calls in flight finish, but stops long running
+// streams such as WatchColor after timeout.
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("calls still running after %v, stopping", timeout)
+		s.Stop()
+	}
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// The metrics follow the names used by go-grpc-prometheus, so existing
+// dashboards work, and add the code to the latency histogram so that it
+// can be compared with Envoy's per status gRPC stats.
+var (
+	serverStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "Total number of RPCs started on the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	serverHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	serverHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency of RPCs handled by the server.",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	serverMsgReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_msg_received_total",
+		Help: "Total number of stream messages received from the client.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	serverMsgSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_msg_sent_total",
+		Help: "Total number of stream messages sent by the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+func init() {
+	prometheus.MustRegister(serverStarted, serverHandled, serverHandlingSeconds, serverMsgReceived, serverMsgSent)
+}
+
+// splitMethod splits /color.ColorService/GetColor into its service and
+// method names.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	case info.IsServerStream:
+		return "server_stream"
+	}
+	return "unary"
+}
+
+func observe(rpcType, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err).String()
+	serverHandled.WithLabelValues(rpcType, service, method, code).Inc()
+	serverHandlingSeconds.WithLabelValues(rpcType, service, method, code).Observe(time.Since(start).Seconds())
+}
+
+func unaryMetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	service, method := splitMethod(info.FullMethod)
+	serverStarted.WithLabelValues("unary", service, method).Inc()
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe("unary", info.FullMethod, start, err)
+	return resp, err
+}
+
+// countingServerStream counts the messages of a stream.
+type countingServerStream struct {
+	grpc.ServerStream
+	received, sent prometheus.Counter
+}
+
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err
+}
+
+func (s *countingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+	return err
+}
+
+func streamMetricsInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	rpcType := streamType(info)
+	service, method := splitMethod(info.FullMethod)
+	serverStarted.WithLabelValues(rpcType, service, method).Inc()
+	start := time.Now()
+	err := handler(srv, &countingServerStream{
+		ServerStream: ss,
+		received:     serverMsgReceived.WithLabelValues(rpcType, service, method),
+		sent:         serverMsgSent.WithLabelValues(rpcType, service, method),
+	})
+	observe(rpcType, info.FullMethod, start, err)
+	return err
+}
+
+// serveMetrics serves /metrics over HTTP on METRICS_PORT, when it is set.
+func serveMetrics() {
+	port := os.Getenv("METRICS_PORT")
+	if port == "" {
+		return
+	}
+	log.Printf("METRICS_PORT is: %v", port)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Fatal(http.ListenAndServe("0.0.0.0:"+port, mux))
+	}()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPayloadSize keeps GetPayload from exhausting the server's memory. It is
+// well above gRPC's 4MB default receive limit.
+const maxPayloadSize = 64 << 20
+
+func (s *colorServer) GetPayload(ctx context.Context, in *pb.GetPayloadRequest) (*pb.GetPayloadResponse, error) {
+	if in.Size > maxPayloadSize {
+		return nil, status.Errorf(codes.InvalidArgument, "size %d is over the limit of %d bytes", in.Size, maxPayloadSize)
+	}
+	payload := make([]byte, in.Size)
+	if in.Compressible {
+		for i := range payload {
+			payload[i] = byte('a' + i%26)
+		}
+	} else {
+		rand.Read(payload)
+	}
+	return &pb.GetPayloadResponse{Payload: payload}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkVersion fails a change made with an expected_version that is no longer
+// current. Zero means the caller doesn't care which version it replaces.
+func checkVersion(name string, current, expected uint64) error {
+	if expected != 0 && expected != current {
+		return status.Errorf(codes.FailedPrecondition, "%s is at version %d, not %d", name, current, expected)
+	}
+	return nil
+}
+
+func (s *colorServer) getColor() (string, uint64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.color, s.colorVersion
+}
+
+// setColor changes the color unless expected doesn't match, and returns the
+// old color and the new version. Watchers are notified while the lock is
+// held, so they see changes in version order.
+func (s *colorServer) setColor(color string, expected uint64) (string, uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := checkVersion("color", s.colorVersion, expected); err != nil {
+		return "", 0, err
+	}
+	oldColor := s.color
+	s.color = color
+	s.colorVersion++
+	s.watchers.notify(s.watchColorResponse())
+	return oldColor, s.colorVersion, nil
+}
+
+// watchColor registers a watcher and returns the color it starts from, so no
+// change can fall between the two.
+func (s *colorServer) watchColor() (int, chan *pb.WatchColorResponse, *pb.WatchColorResponse) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	id, changes := s.watchers.add()
+	return id, changes, s.watchColorResponse()
+}
+
+// watchColorResponse describes the current color. It needs the lock.
+func (s *colorServer) watchColorResponse() *pb.WatchColorResponse {
+	return &pb.WatchColorResponse{Color: enumOf(s.color), Version: s.colorVersion, Info: s.catalog.info(s.color)}
+}
+
+func (s *colorServer) getFaults() (*faults, uint64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.faults, s.flakinessVersion
+}
+
+// setFaults replaces the flakiness unless expected doesn't match, and returns
+// the old configuration and the new version.
+func (s *colorServer) setFaults(config *pb.Flakiness, expected uint64) (*pb.Flakiness, uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := checkVersion("flakiness", s.flakinessVersion, expected); err != nil {
+		return nil, 0, err
+	}
+	oldFlakiness := s.faults.config
+	s.faults = newFaults(config)
+	s.flakinessVersion++
+	return oldFlakiness, s.flakinessVersion, nil
+}
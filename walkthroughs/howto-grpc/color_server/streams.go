@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"log"
+	"sync"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_server/color"
+)
+
+// colorWatchers fans color changes out to every open WatchColor stream.
+type colorWatchers struct {
+	mutex    sync.Mutex
+	nextID   int
+	watchers map[int]chan *pb.WatchColorResponse
+}
+
+func (w *colorWatchers) add() (int, chan *pb.WatchColorResponse) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[int]chan *pb.WatchColorResponse)
+	}
+	id := w.nextID
+	w.nextID++
+	// the buffer lets SetColor return without waiting on slow watchers, see
+	// notify
+	ch := make(chan *pb.WatchColorResponse, 1)
+	w.watchers[id] = ch
+	return id, ch
+}
+
+func (w *colorWatchers) remove(id int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.watchers, id)
+}
+
+func (w *colorWatchers) notify(change *pb.WatchColorResponse) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, ch := range w.watchers {
+		// a watcher only needs the latest color, so a change it hasn't read
+		// yet is replaced rather than queued. Only notify sends, under the
+		// mutex, so the send can't block once the buffer is empty.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- change
+	}
+}
+
+func (s *colorServer) WatchColor(in *pb.WatchColorRequest, stream pb.ColorService_WatchColorServer) error {
+	id, changes, current := s.watchColor()
+	defer s.watchers.remove(id)
+
+	if err := stream.Send(current); err != nil {
+		return err
+	}
+	for {
+		select {
+		case change := <-changes:
+			if err := stream.Send(change); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			log.Printf("WatchColor stream closed: %v", stream.Context().Err())
+			return nil
+		}
+	}
+}
+
+func (s *colorServer) ReportColors(stream pb.ColorService_ReportColorsServer) error {
+	resp := &pb.ReportColorsResponse{Counts: make(map[string]int32)}
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			log.Printf("Finished ReportColors: %v", resp)
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+		resp.Total++
+		resp.Counts[in.Color.String()]++
+	}
+}
+
+func (s *colorServer) ColorChat(stream pb.ColorService_ColorChatServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, _ := s.getColor()
+		color := enumOf(name)
+		if err := stream.Send(&pb.ColorChatResponse{Sent: in.Color, Color: color, Match: in.Color == color}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsFiles loads a certificate, its key and an optional CA bundle, and loads
+// them again whenever one of the files changes, so they can be rotated
+// without a restart. A failed reload keeps the files loaded before it.
+type tlsFiles struct {
+	certFile, keyFile, caFile string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+func (f *tlsFiles) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{f.certFile, f.keyFile, f.caFile} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (f *tlsFiles) load() (*tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.caFile == "" {
+		return &cert, nil, nil
+	}
+	pem, err := ioutil.ReadFile(f.caFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, nil, fmt.Errorf("no certificates found in %s", f.caFile)
+	}
+	return &cert, pool, nil
+}
+
+func (f *tlsFiles) get() (*tls.Certificate, *x509.CertPool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	modTime, err := f.latestModTime()
+	if err == nil && !modTime.After(f.modTime) {
+		return f.cert, f.pool, nil
+	}
+	if err == nil {
+		var cert *tls.Certificate
+		var pool *x509.CertPool
+		if cert, pool, err = f.load(); err == nil {
+			log.Printf("Loaded TLS files %s, %s, %s", f.certFile, f.keyFile, f.caFile)
+			f.cert, f.pool, f.modTime = cert, pool, modTime
+			return f.cert, f.pool, nil
+		}
+	}
+	if f.cert == nil {
+		return nil, nil, err
+	}
+	log.Printf("Keeping previous TLS files, failed to reload: %v", err)
+	return f.cert, f.pool, nil
+}
+
+// peerIdentity describes a certificate for the logs, preferring the SPIFFE
+// ID or DNS name over the subject.
+func peerIdentity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		return uri.String()
+	}
+	for _, name := range cert.DNSNames {
+		return name
+	}
+	return cert.Subject.String()
+}
+
+// getServerCredentials serves TLS when TLS_CERT and TLS_KEY are set, and
+// requires client certificates signed by TLS_CA when it is set too. It returns
+// nil when TLS is off.
+func getServerCredentials() (credentials.TransportCredentials, error) {
+	files := &tlsFiles{
+		certFile: os.Getenv("TLS_CERT"),
+		keyFile:  os.Getenv("TLS_KEY"),
+		caFile:   os.Getenv("TLS_CA"),
+	}
+	if files.certFile == "" && files.keyFile == "" {
+		if files.caFile != "" {
+			return nil, fmt.Errorf("TLS_CA needs TLS_CERT and TLS_KEY")
+		}
+		return nil, nil
+	}
+	if files.certFile == "" || files.keyFile == "" {
+		return nil, fmt.Errorf("TLS_CERT and TLS_KEY must be set together")
+	}
+	// fail at startup rather than on the first handshake
+	if _, _, err := files.get(); err != nil {
+		return nil, err
+	}
+	log.Printf("TLS is on, client certificates are required: %v", files.caFile != "")
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool, err := files.get()
+			if err != nil {
+				return nil, err
+			}
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   []string{"h2"},
+				VerifyConnection: func(state tls.ConnectionState) error {
+					if len(state.PeerCertificates) > 0 {
+						log.Printf("TLS handshake with %s from %s", peerIdentity(state.PeerCertificates[0]), hello.Conn.RemoteAddr())
+					}
+					return nil
+				},
+			}
+			if pool != nil {
+				config.ClientCAs = pool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+// getDuration reads an optional duration from name, e.g. KEEPALIVE_TIME=30s.
+func getDuration(name string) (time.Duration, bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	log.Printf("%s is: %v", name, d)
+	return d, true, nil
+}
+
+// getSize reads an optional message size in bytes from name.
+func getSize(name string) (int, bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, false, fmt.Errorf("invalid %s %q, it must be a number of bytes", name, value)
+	}
+	log.Printf("%s is: %v", name, n)
+	return n, true, nil
+}
+
+func getBool(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	log.Printf("%s is: %v", name, b)
+	return b, nil
+}
+
+// getTransportOptions configures keepalive, message size limits and
+// compression from the environment. gRPC's defaults apply to anything unset:
+// no server pings, no connection age limits, clients may ping at most every
+// 5 minutes, and messages received may be up to 4MB.
+func getTransportOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	var params keepalive.ServerParameters
+	var policy keepalive.EnforcementPolicy
+	setParams, setPolicy := false, false
+	for _, d := range []struct {
+		name  string
+		value *time.Duration
+		set   *bool
+	}{
+		{"KEEPALIVE_TIME", &params.Time, &setParams},
+		{"KEEPALIVE_TIMEOUT", &params.Timeout, &setParams},
+		{"MAX_CONNECTION_IDLE", &params.MaxConnectionIdle, &setParams},
+		{"MAX_CONNECTION_AGE", &params.MaxConnectionAge, &setParams},
+		{"MAX_CONNECTION_AGE_GRACE", &params.MaxConnectionAgeGrace, &setParams},
+		{"KEEPALIVE_MIN_TIME", &policy.MinTime, &setPolicy},
+	} {
+		value, ok, err := getDuration(d.name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*d.value = value
+			*d.set = true
+		}
+	}
+	permit, err := getBool("KEEPALIVE_PERMIT_WITHOUT_STREAM")
+	if err != nil {
+		return nil, err
+	}
+	if permit {
+		policy.PermitWithoutStream = true
+		setPolicy = true
+	}
+	if setParams {
+		opts = append(opts, grpc.KeepaliveParams(params))
+	}
+	if setPolicy {
+		if policy.MinTime == 0 {
+			// keep gRPC's default rather than allowing pings at any rate
+			policy.MinTime = 5 * time.Minute
+		}
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+
+	if n, ok, err := getSize("MAX_RECV_MSG_SIZE"); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, grpc.MaxRecvMsgSize(n))
+	}
+	if n, ok, err := getSize("MAX_SEND_MSG_SIZE"); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, grpc.MaxSendMsgSize(n))
+	}
+
+	switch compression := os.Getenv("COMPRESSION"); compression {
+	case "", "identity":
+	case gzip.Name:
+		log.Printf("COMPRESSION is: %v", compression)
+		sendCompressor = compression
+	default:
+		return nil, fmt.Errorf("invalid COMPRESSION %q, it must be gzip or identity", compression)
+	}
+	return opts, nil
+}
+
+// sendCompressor compresses responses to clients that accept it, even when
+// their requests aren't compressed. Importing the gzip package lets the
+// server accept gzip requests and answer them in kind either way.
+var sendCompressor string
+
+func setSendCompressor(ctx context.Context) {
+	if sendCompressor == "" {
+		return
+	}
+	accepted, _ := grpc.ClientSupportedCompressors(ctx)
+	for _, name := range accepted {
+		if name == sendCompressor {
+			if err := grpc.SetSendCompressor(ctx, sendCompressor); err != nil {
+				log.Printf("Failed to set the send compressor: %v", err)
+			}
+			return
+		}
+	}
+}
+
+func unaryCompressionInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	setSendCompressor(ctx)
+	return handler(ctx, req)
+}
+
+func streamCompressionInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	setSendCompressor(ss.Context())
+	return handler(srv, ss)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// benchResult summarizes a bench run.
+type benchResult struct {
+	Calls     int            `json:"calls"`
+	Elapsed   string         `json:"elapsed"`
+	PerSecond float64        `json:"perSecond"`
+	Colors    map[string]int `json:"colors"`
+	Codes     map[string]int `json:"codes"`
+	P50       string         `json:"p50"`
+	P90       string         `json:"p90"`
+	P99       string         `json:"p99"`
+	Max       string         `json:"max"`
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted))*p/100+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// bench makes n calls, c at a time, and reports how many returned each color
+// and each status code.
+func bench(ctx context.Context, o *options, s *colorService, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	n := fs.Int("n", 100, "number of calls")
+	c := fs.Int("c", 10, "number of calls in flight at once")
+	method := fs.String("method", "GetColor", "unary method to call with an empty request")
+	fs.Parse(args)
+	if *n <= 0 || *c <= 0 {
+		return fmt.Errorf("-n and -c must be positive")
+	}
+	if _, err := s.newRequest(*method); err != nil {
+		return err
+	}
+
+	var mutex sync.Mutex
+	result := &benchResult{Colors: make(map[string]int), Codes: make(map[string]int)}
+	var latencies []time.Duration
+	calls := make(chan struct{})
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < *c; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range calls {
+				req, _ := s.newRequest(*method)
+				callCtx, cancel := o.callContext(ctx)
+				callStart := time.Now()
+				resp, err := s.call(callCtx, *method, req)
+				latency := time.Since(callStart)
+				cancel()
+
+				mutex.Lock()
+				result.Calls++
+				latencies = append(latencies, latency)
+				result.Codes[status.Code(err).String()]++
+				if err == nil {
+					if color := colorName(resp); color != "" {
+						result.Colors[color]++
+					}
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < *n && ctx.Err() == nil; i++ {
+		calls <- struct{}{}
+	}
+	close(calls)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result.Elapsed = elapsed.Round(time.Millisecond).String()
+	result.PerSecond = float64(result.Calls) / elapsed.Seconds()
+	result.P50 = percentile(latencies, 50).String()
+	result.P90 = percentile(latencies, 90).String()
+	result.P99 = percentile(latencies, 99).String()
+	result.Max = percentile(latencies, 100).String()
+
+	if o.json {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	fmt.Printf("%d calls to %s in %s (%.1f/s)\n", result.Calls, *method, result.Elapsed, result.PerSecond)
+	fmt.Printf("latency p50 %s, p90 %s, p99 %s, max %s\n\n", result.P50, result.P90, result.P99, result.Max)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	printDistribution(w, "CODE", result.Codes, result.Calls)
+	if len(result.Colors) > 0 {
+		fmt.Fprintln(w)
+		printDistribution(w, "COLOR", result.Colors, result.Calls)
+	}
+	return w.Flush()
+}
+
+func printDistribution(w *tabwriter.Writer, title string, counts map[string]int, total int) {
+	var keys []string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	// most frequent first
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	fmt.Fprintf(w, "%s\tCALLS\tPERCENT\n", title)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s\t%d\t%.1f%%\n", k, counts[k], 100*float64(counts[k])/float64(total))
+	}
+}
@@ -0,0 +1,2044 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.17.1
+// source: color.proto
+
+package color
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Color is kept for existing clients. The server's catalog can have colors
+// beyond it, which are NO_COLOR here and named by ColorInfo.
+type Color int32
+
+const (
+	Color_NO_COLOR Color = 0
+	Color_RED      Color = 1
+	Color_BLUE     Color = 2
+	Color_GREEN    Color = 3
+	Color_YELLOW   Color = 4
+	Color_ORANGE   Color = 5
+	Color_PURPLE   Color = 6
+	Color_PINK     Color = 7
+	Color_BLACK    Color = 8
+	Color_WHITE    Color = 9
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "NO_COLOR",
+		1: "RED",
+		2: "BLUE",
+		3: "GREEN",
+		4: "YELLOW",
+		5: "ORANGE",
+		6: "PURPLE",
+		7: "PINK",
+		8: "BLACK",
+		9: "WHITE",
+	}
+	Color_value = map[string]int32{
+		"NO_COLOR": 0,
+		"RED":      1,
+		"BLUE":     2,
+		"GREEN":    3,
+		"YELLOW":   4,
+		"ORANGE":   5,
+		"PURPLE":   6,
+		"PINK":     7,
+		"BLACK":    8,
+		"WHITE":    9,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_color_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_color_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{0}
+}
+
+// ServingStatus mirrors grpc.health.v1.HealthCheckResponse.ServingStatus.
+type ServingStatus int32
+
+const (
+	ServingStatus_UNKNOWN         ServingStatus = 0
+	ServingStatus_SERVING         ServingStatus = 1
+	ServingStatus_NOT_SERVING     ServingStatus = 2
+	ServingStatus_SERVICE_UNKNOWN ServingStatus = 3
+)
+
+// Enum value maps for ServingStatus.
+var (
+	ServingStatus_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SERVING",
+		2: "NOT_SERVING",
+		3: "SERVICE_UNKNOWN",
+	}
+	ServingStatus_value = map[string]int32{
+		"UNKNOWN":         0,
+		"SERVING":         1,
+		"NOT_SERVING":     2,
+		"SERVICE_UNKNOWN": 3,
+	}
+)
+
+func (x ServingStatus) Enum() *ServingStatus {
+	p := new(ServingStatus)
+	*p = x
+	return p
+}
+
+func (x ServingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_color_proto_enumTypes[1].Descriptor()
+}
+
+func (ServingStatus) Type() protoreflect.EnumType {
+	return &file_color_proto_enumTypes[1]
+}
+
+func (x ServingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServingStatus.Descriptor instead.
+func (ServingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{1}
+}
+
+// ColorInfo is a color of the server's catalog.
+type ColorInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name is the lowercase catalog name, e.g. red or teal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// hex is the RGB value, e.g. #ff0000.
+	Hex string `protobuf:"bytes,2,opt,name=hex,proto3" json:"hex,omitempty"`
+	// labels are those of the server that answered, e.g. version and zone.
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ColorInfo) Reset() {
+	*x = ColorInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorInfo) ProtoMessage() {}
+
+func (x *ColorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorInfo.ProtoReflect.Descriptor instead.
+func (*ColorInfo) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ColorInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColorInfo) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ColorInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type GetColorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetColorRequest) Reset() {
+	*x = GetColorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetColorRequest) ProtoMessage() {}
+
+func (x *GetColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetColorRequest.ProtoReflect.Descriptor instead.
+func (*GetColorRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{1}
+}
+
+type GetColorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+	// version is incremented by every change to the color, starting from 1.
+	Version uint64     `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Info    *ColorInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *GetColorResponse) Reset() {
+	*x = GetColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetColorResponse) ProtoMessage() {}
+
+func (x *GetColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetColorResponse.ProtoReflect.Descriptor instead.
+func (*GetColorResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetColorResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+func (x *GetColorResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetColorResponse) GetInfo() *ColorInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type SetColorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// color or name is the color to set. Either must be in the catalog, or
+	// the change fails with INVALID_ARGUMENT. NO_COLOR clears the color.
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+	// expected_version, if set, makes the change fail with FAILED_PRECONDITION
+	// unless the color is still at this version.
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Name            string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SetColorRequest) Reset() {
+	*x = SetColorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetColorRequest) ProtoMessage() {}
+
+func (x *SetColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetColorRequest.ProtoReflect.Descriptor instead.
+func (*SetColorRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetColorRequest) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+func (x *SetColorRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *SetColorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SetColorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// color is the color before the change.
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+	// version is the version after the change.
+	Version uint64     `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Info    *ColorInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *SetColorResponse) Reset() {
+	*x = SetColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetColorResponse) ProtoMessage() {}
+
+func (x *SetColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetColorResponse.ProtoReflect.Descriptor instead.
+func (*SetColorResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetColorResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+func (x *SetColorResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SetColorResponse) GetInfo() *ColorInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type ListColorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListColorsRequest) Reset() {
+	*x = ListColorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListColorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListColorsRequest) ProtoMessage() {}
+
+func (x *ListColorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListColorsRequest.ProtoReflect.Descriptor instead.
+func (*ListColorsRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{5}
+}
+
+type ListColorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Colors []*ColorInfo `protobuf:"bytes,1,rep,name=colors,proto3" json:"colors,omitempty"`
+}
+
+func (x *ListColorsResponse) Reset() {
+	*x = ListColorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListColorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListColorsResponse) ProtoMessage() {}
+
+func (x *ListColorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListColorsResponse.ProtoReflect.Descriptor instead.
+func (*ListColorsResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListColorsResponse) GetColors() []*ColorInfo {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+// Flakiness describes the faults the Color Server injects. A call fails with
+// code when any of the failure triggers fires: rate, every_nth, the burst
+// window or the metadata trigger.
+type Flakiness struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rate is the fraction of calls to fail at random, from 0.0 to 1.0.
+	Rate float32 `protobuf:"fixed32,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	// code is the gRPC status code failed calls return.
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// latency_ms is added to every targeted call, failed or not.
+	LatencyMs uint32 `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// latency_jitter_ms adds a uniformly distributed extra 0 to latency_ms.
+	LatencyJitterMs uint32 `protobuf:"varint,4,opt,name=latency_jitter_ms,json=latencyJitterMs,proto3" json:"latency_jitter_ms,omitempty"`
+	// methods lists the methods to target, by name (GetColor) or full name
+	// (/color.ColorService/GetColor). Only GetColor is targeted when empty.
+	Methods []string `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	// every_nth fails every Nth targeted call.
+	EveryNth uint32 `protobuf:"varint,6,opt,name=every_nth,json=everyNth,proto3" json:"every_nth,omitempty"`
+	// burst_ms fails every targeted call during the first burst_ms of each
+	// burst_period_ms, counted from when the flakiness was set.
+	BurstMs       uint32 `protobuf:"varint,7,opt,name=burst_ms,json=burstMs,proto3" json:"burst_ms,omitempty"`
+	BurstPeriodMs uint32 `protobuf:"varint,8,opt,name=burst_period_ms,json=burstPeriodMs,proto3" json:"burst_period_ms,omitempty"`
+	// metadata_key fails every targeted call carrying this metadata key, and
+	// metadata_value, if set, only those where the key has this value.
+	MetadataKey   string `protobuf:"bytes,9,opt,name=metadata_key,json=metadataKey,proto3" json:"metadata_key,omitempty"`
+	MetadataValue string `protobuf:"bytes,10,opt,name=metadata_value,json=metadataValue,proto3" json:"metadata_value,omitempty"`
+	// The fields below add details to the failures. retry_delay_ms attaches a
+	// google.rpc.RetryInfo asking the client to wait that long before retrying,
+	// and retry_pushback sends the same delay in the grpc-retry-pushback-ms
+	// trailer, which gRPC clients' own retries honor.
+	RetryDelayMs  uint32 `protobuf:"varint,11,opt,name=retry_delay_ms,json=retryDelayMs,proto3" json:"retry_delay_ms,omitempty"`
+	RetryPushback bool   `protobuf:"varint,12,opt,name=retry_pushback,json=retryPushback,proto3" json:"retry_pushback,omitempty"`
+	// error_reason attaches a google.rpc.ErrorInfo with this reason, in
+	// error_domain or color.howto-grpc.local when that isn't set.
+	ErrorReason string `protobuf:"bytes,13,opt,name=error_reason,json=errorReason,proto3" json:"error_reason,omitempty"`
+	ErrorDomain string `protobuf:"bytes,14,opt,name=error_domain,json=errorDomain,proto3" json:"error_domain,omitempty"`
+	// quota_violations attaches a google.rpc.QuotaFailure with one violation
+	// per entry, each a subject with an optional description after the first
+	// colon, e.g. "GetColor calls per client:at most 10 a second".
+	QuotaViolations []string `protobuf:"bytes,15,rep,name=quota_violations,json=quotaViolations,proto3" json:"quota_violations,omitempty"`
+	// trailers are key=value pairs added to the trailers of failed calls.
+	Trailers []string `protobuf:"bytes,16,rep,name=trailers,proto3" json:"trailers,omitempty"`
+}
+
+func (x *Flakiness) Reset() {
+	*x = Flakiness{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Flakiness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flakiness) ProtoMessage() {}
+
+func (x *Flakiness) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flakiness.ProtoReflect.Descriptor instead.
+func (*Flakiness) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Flakiness) GetRate() float32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Flakiness) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Flakiness) GetLatencyMs() uint32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *Flakiness) GetLatencyJitterMs() uint32 {
+	if x != nil {
+		return x.LatencyJitterMs
+	}
+	return 0
+}
+
+func (x *Flakiness) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *Flakiness) GetEveryNth() uint32 {
+	if x != nil {
+		return x.EveryNth
+	}
+	return 0
+}
+
+func (x *Flakiness) GetBurstMs() uint32 {
+	if x != nil {
+		return x.BurstMs
+	}
+	return 0
+}
+
+func (x *Flakiness) GetBurstPeriodMs() uint32 {
+	if x != nil {
+		return x.BurstPeriodMs
+	}
+	return 0
+}
+
+func (x *Flakiness) GetMetadataKey() string {
+	if x != nil {
+		return x.MetadataKey
+	}
+	return ""
+}
+
+func (x *Flakiness) GetMetadataValue() string {
+	if x != nil {
+		return x.MetadataValue
+	}
+	return ""
+}
+
+func (x *Flakiness) GetRetryDelayMs() uint32 {
+	if x != nil {
+		return x.RetryDelayMs
+	}
+	return 0
+}
+
+func (x *Flakiness) GetRetryPushback() bool {
+	if x != nil {
+		return x.RetryPushback
+	}
+	return false
+}
+
+func (x *Flakiness) GetErrorReason() string {
+	if x != nil {
+		return x.ErrorReason
+	}
+	return ""
+}
+
+func (x *Flakiness) GetErrorDomain() string {
+	if x != nil {
+		return x.ErrorDomain
+	}
+	return ""
+}
+
+func (x *Flakiness) GetQuotaViolations() []string {
+	if x != nil {
+		return x.QuotaViolations
+	}
+	return nil
+}
+
+func (x *Flakiness) GetTrailers() []string {
+	if x != nil {
+		return x.Trailers
+	}
+	return nil
+}
+
+type GetFlakinessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetFlakinessRequest) Reset() {
+	*x = GetFlakinessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFlakinessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlakinessRequest) ProtoMessage() {}
+
+func (x *GetFlakinessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlakinessRequest.ProtoReflect.Descriptor instead.
+func (*GetFlakinessRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{8}
+}
+
+type GetFlakinessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+	// version is incremented by every change to the flakiness, starting from 1.
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetFlakinessResponse) Reset() {
+	*x = GetFlakinessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFlakinessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlakinessResponse) ProtoMessage() {}
+
+func (x *GetFlakinessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlakinessResponse.ProtoReflect.Descriptor instead.
+func (*GetFlakinessResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFlakinessResponse) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
+	}
+	return nil
+}
+
+func (x *GetFlakinessResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SetFlakinessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+	// expected_version, if set, makes the change fail with FAILED_PRECONDITION
+	// unless the flakiness is still at this version.
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *SetFlakinessRequest) Reset() {
+	*x = SetFlakinessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFlakinessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFlakinessRequest) ProtoMessage() {}
+
+func (x *SetFlakinessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFlakinessRequest.ProtoReflect.Descriptor instead.
+func (*SetFlakinessRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetFlakinessRequest) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
+	}
+	return nil
+}
+
+func (x *SetFlakinessRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type SetFlakinessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// flakiness is the flakiness before the change.
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+	// version is the version after the change.
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *SetFlakinessResponse) Reset() {
+	*x = SetFlakinessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFlakinessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFlakinessResponse) ProtoMessage() {}
+
+func (x *SetFlakinessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFlakinessResponse.ProtoReflect.Descriptor instead.
+func (*SetFlakinessResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetFlakinessResponse) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
+	}
+	return nil
+}
+
+func (x *SetFlakinessResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type WatchColorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchColorRequest) Reset() {
+	*x = WatchColorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchColorRequest) ProtoMessage() {}
+
+func (x *WatchColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchColorRequest.ProtoReflect.Descriptor instead.
+func (*WatchColorRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{12}
+}
+
+type WatchColorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color   Color      `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+	Version uint64     `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Info    *ColorInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *WatchColorResponse) Reset() {
+	*x = WatchColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchColorResponse) ProtoMessage() {}
+
+func (x *WatchColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchColorResponse.ProtoReflect.Descriptor instead.
+func (*WatchColorResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchColorResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+func (x *WatchColorResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *WatchColorResponse) GetInfo() *ColorInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type ReportColorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+}
+
+func (x *ReportColorsRequest) Reset() {
+	*x = ReportColorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportColorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportColorsRequest) ProtoMessage() {}
+
+func (x *ReportColorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportColorsRequest.ProtoReflect.Descriptor instead.
+func (*ReportColorsRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReportColorsRequest) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+type ReportColorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total  int32            `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Counts map[string]int32 `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *ReportColorsResponse) Reset() {
+	*x = ReportColorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportColorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportColorsResponse) ProtoMessage() {}
+
+func (x *ReportColorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportColorsResponse.ProtoReflect.Descriptor instead.
+func (*ReportColorsResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReportColorsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ReportColorsResponse) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type ColorChatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+}
+
+func (x *ColorChatRequest) Reset() {
+	*x = ColorChatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColorChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorChatRequest) ProtoMessage() {}
+
+func (x *ColorChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorChatRequest.ProtoReflect.Descriptor instead.
+func (*ColorChatRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ColorChatRequest) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+type ColorChatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sent  Color `protobuf:"varint,1,opt,name=sent,proto3,enum=color.Color" json:"sent,omitempty"`
+	Color Color `protobuf:"varint,2,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
+	Match bool  `protobuf:"varint,3,opt,name=match,proto3" json:"match,omitempty"`
+}
+
+func (x *ColorChatResponse) Reset() {
+	*x = ColorChatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColorChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorChatResponse) ProtoMessage() {}
+
+func (x *ColorChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorChatResponse.ProtoReflect.Descriptor instead.
+func (*ColorChatResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ColorChatResponse) GetSent() Color {
+	if x != nil {
+		return x.Sent
+	}
+	return Color_NO_COLOR
+}
+
+func (x *ColorChatResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_NO_COLOR
+}
+
+func (x *ColorChatResponse) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+type SetHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// service is the name health checks ask about, empty for the server as a whole.
+	Service string        `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Status  ServingStatus `protobuf:"varint,2,opt,name=status,proto3,enum=color.ServingStatus" json:"status,omitempty"`
+}
+
+func (x *SetHealthRequest) Reset() {
+	*x = SetHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHealthRequest) ProtoMessage() {}
+
+func (x *SetHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHealthRequest.ProtoReflect.Descriptor instead.
+func (*SetHealthRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetHealthRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SetHealthRequest) GetStatus() ServingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServingStatus_UNKNOWN
+}
+
+type SetHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status ServingStatus `protobuf:"varint,1,opt,name=status,proto3,enum=color.ServingStatus" json:"status,omitempty"`
+}
+
+func (x *SetHealthResponse) Reset() {
+	*x = SetHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHealthResponse) ProtoMessage() {}
+
+func (x *SetHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHealthResponse.ProtoReflect.Descriptor instead.
+func (*SetHealthResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetHealthResponse) GetStatus() ServingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServingStatus_UNKNOWN
+}
+
+type GetPayloadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// size is the length of the payload in bytes.
+	Size uint32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// compressible fills the payload with a repeating pattern instead of
+	// random bytes, so that compression shrinks it.
+	Compressible bool `protobuf:"varint,2,opt,name=compressible,proto3" json:"compressible,omitempty"`
+}
+
+func (x *GetPayloadRequest) Reset() {
+	*x = GetPayloadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPayloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayloadRequest) ProtoMessage() {}
+
+func (x *GetPayloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayloadRequest.ProtoReflect.Descriptor instead.
+func (*GetPayloadRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPayloadRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetPayloadRequest) GetCompressible() bool {
+	if x != nil {
+		return x.Compressible
+	}
+	return false
+}
+
+type GetPayloadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *GetPayloadResponse) Reset() {
+	*x = GetPayloadResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPayloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayloadResponse) ProtoMessage() {}
+
+func (x *GetPayloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayloadResponse.ProtoReflect.Descriptor instead.
+func (*GetPayloadResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPayloadResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_color_proto protoreflect.FileDescriptor
+
+var file_color_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x65, 0x78, 0x12, 0x34, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x76, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x22, 0x74, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x76, 0x0a, 0x10, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22,
+	0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a,
+	0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x22, 0x9c, 0x04, 0x0a, 0x09, 0x46, 0x6c, 0x61, 0x6b,
+	0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x6e, 0x74, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x76, 0x65, 0x72, 0x79, 0x4e, 0x74, 0x68, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x75, 0x72, 0x73, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x62, 0x75, 0x72, 0x73, 0x74, 0x4d, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x75,
+	0x72, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x62, 0x75, 0x72, 0x73, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x4d, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x4b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0e,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x75, 0x73, 0x68,
+	0x62, 0x61, 0x63, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x50, 0x75, 0x73, 0x68, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x71, 0x75, 0x6f, 0x74, 0x61,
+	0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x72,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x74, 0x72,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x60, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x6b,
+	0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x70, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09, 0x66, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x60, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09,
+	0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x13, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x78, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22,
+	0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x22, 0x39, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0xa8, 0x01,
+	0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x3f, 0x0a, 0x06,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x1a, 0x39, 0x0a,
+	0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x36, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x22, 0x6f, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x22, 0x5a, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x41, 0x0a,
+	0x11, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x4b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x22, 0x2e, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2a, 0x77, 0x0a,
+	0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x43, 0x4f, 0x4c,
+	0x4f, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a,
+	0x04, 0x42, 0x4c, 0x55, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x45, 0x45, 0x4e,
+	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x59, 0x45, 0x4c, 0x4c, 0x4f, 0x57, 0x10, 0x04, 0x12, 0x0a,
+	0x0a, 0x06, 0x4f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55,
+	0x52, 0x50, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x49, 0x4e, 0x4b, 0x10, 0x07,
+	0x12, 0x09, 0x0a, 0x05, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x08, 0x12, 0x09, 0x0a, 0x05, 0x57,
+	0x48, 0x49, 0x54, 0x45, 0x10, 0x09, 0x2a, 0x4f, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x32, 0xc8, 0x05, 0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6c,
+	0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x49, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x12, 0x44, 0x0a, 0x09, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x43, 0x68, 0x61, 0x74, 0x12, 0x17, 0x2e,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x43, 0x68, 0x61, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x0a, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_color_proto_rawDescOnce sync.Once
+	file_color_proto_rawDescData = file_color_proto_rawDesc
+)
+
+func file_color_proto_rawDescGZIP() []byte {
+	file_color_proto_rawDescOnce.Do(func() {
+		file_color_proto_rawDescData = protoimpl.X.CompressGZIP(file_color_proto_rawDescData)
+	})
+	return file_color_proto_rawDescData
+}
+
+var file_color_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_color_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_color_proto_goTypes = []interface{}{
+	(Color)(0),                   // 0: color.Color
+	(ServingStatus)(0),           // 1: color.ServingStatus
+	(*ColorInfo)(nil),            // 2: color.ColorInfo
+	(*GetColorRequest)(nil),      // 3: color.GetColorRequest
+	(*GetColorResponse)(nil),     // 4: color.GetColorResponse
+	(*SetColorRequest)(nil),      // 5: color.SetColorRequest
+	(*SetColorResponse)(nil),     // 6: color.SetColorResponse
+	(*ListColorsRequest)(nil),    // 7: color.ListColorsRequest
+	(*ListColorsResponse)(nil),   // 8: color.ListColorsResponse
+	(*Flakiness)(nil),            // 9: color.Flakiness
+	(*GetFlakinessRequest)(nil),  // 10: color.GetFlakinessRequest
+	(*GetFlakinessResponse)(nil), // 11: color.GetFlakinessResponse
+	(*SetFlakinessRequest)(nil),  // 12: color.SetFlakinessRequest
+	(*SetFlakinessResponse)(nil), // 13: color.SetFlakinessResponse
+	(*WatchColorRequest)(nil),    // 14: color.WatchColorRequest
+	(*WatchColorResponse)(nil),   // 15: color.WatchColorResponse
+	(*ReportColorsRequest)(nil),  // 16: color.ReportColorsRequest
+	(*ReportColorsResponse)(nil), // 17: color.ReportColorsResponse
+	(*ColorChatRequest)(nil),     // 18: color.ColorChatRequest
+	(*ColorChatResponse)(nil),    // 19: color.ColorChatResponse
+	(*SetHealthRequest)(nil),     // 20: color.SetHealthRequest
+	(*SetHealthResponse)(nil),    // 21: color.SetHealthResponse
+	(*GetPayloadRequest)(nil),    // 22: color.GetPayloadRequest
+	(*GetPayloadResponse)(nil),   // 23: color.GetPayloadResponse
+	nil,                          // 24: color.ColorInfo.LabelsEntry
+	nil,                          // 25: color.ReportColorsResponse.CountsEntry
+}
+var file_color_proto_depIdxs = []int32{
+	24, // 0: color.ColorInfo.labels:type_name -> color.ColorInfo.LabelsEntry
+	0,  // 1: color.GetColorResponse.color:type_name -> color.Color
+	2,  // 2: color.GetColorResponse.info:type_name -> color.ColorInfo
+	0,  // 3: color.SetColorRequest.color:type_name -> color.Color
+	0,  // 4: color.SetColorResponse.color:type_name -> color.Color
+	2,  // 5: color.SetColorResponse.info:type_name -> color.ColorInfo
+	2,  // 6: color.ListColorsResponse.colors:type_name -> color.ColorInfo
+	9,  // 7: color.GetFlakinessResponse.flakiness:type_name -> color.Flakiness
+	9,  // 8: color.SetFlakinessRequest.flakiness:type_name -> color.Flakiness
+	9,  // 9: color.SetFlakinessResponse.flakiness:type_name -> color.Flakiness
+	0,  // 10: color.WatchColorResponse.color:type_name -> color.Color
+	2,  // 11: color.WatchColorResponse.info:type_name -> color.ColorInfo
+	0,  // 12: color.ReportColorsRequest.color:type_name -> color.Color
+	25, // 13: color.ReportColorsResponse.counts:type_name -> color.ReportColorsResponse.CountsEntry
+	0,  // 14: color.ColorChatRequest.color:type_name -> color.Color
+	0,  // 15: color.ColorChatResponse.sent:type_name -> color.Color
+	0,  // 16: color.ColorChatResponse.color:type_name -> color.Color
+	1,  // 17: color.SetHealthRequest.status:type_name -> color.ServingStatus
+	1,  // 18: color.SetHealthResponse.status:type_name -> color.ServingStatus
+	3,  // 19: color.ColorService.GetColor:input_type -> color.GetColorRequest
+	5,  // 20: color.ColorService.SetColor:input_type -> color.SetColorRequest
+	10, // 21: color.ColorService.GetFlakiness:input_type -> color.GetFlakinessRequest
+	12, // 22: color.ColorService.SetFlakiness:input_type -> color.SetFlakinessRequest
+	14, // 23: color.ColorService.WatchColor:input_type -> color.WatchColorRequest
+	16, // 24: color.ColorService.ReportColors:input_type -> color.ReportColorsRequest
+	18, // 25: color.ColorService.ColorChat:input_type -> color.ColorChatRequest
+	20, // 26: color.ColorService.SetHealth:input_type -> color.SetHealthRequest
+	22, // 27: color.ColorService.GetPayload:input_type -> color.GetPayloadRequest
+	7,  // 28: color.ColorService.ListColors:input_type -> color.ListColorsRequest
+	4,  // 29: color.ColorService.GetColor:output_type -> color.GetColorResponse
+	6,  // 30: color.ColorService.SetColor:output_type -> color.SetColorResponse
+	11, // 31: color.ColorService.GetFlakiness:output_type -> color.GetFlakinessResponse
+	13, // 32: color.ColorService.SetFlakiness:output_type -> color.SetFlakinessResponse
+	15, // 33: color.ColorService.WatchColor:output_type -> color.WatchColorResponse
+	17, // 34: color.ColorService.ReportColors:output_type -> color.ReportColorsResponse
+	19, // 35: color.ColorService.ColorChat:output_type -> color.ColorChatResponse
+	21, // 36: color.ColorService.SetHealth:output_type -> color.SetHealthResponse
+	23, // 37: color.ColorService.GetPayload:output_type -> color.GetPayloadResponse
+	8,  // 38: color.ColorService.ListColors:output_type -> color.ListColorsResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_color_proto_init() }
+func file_color_proto_init() {
+	if File_color_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_color_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColorInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetColorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetColorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetColorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetColorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListColorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListColorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Flakiness); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlakinessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlakinessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFlakinessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFlakinessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchColorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchColorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportColorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportColorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColorChatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColorChatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPayloadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPayloadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_color_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_color_proto_goTypes,
+		DependencyIndexes: file_color_proto_depIdxs,
+		EnumInfos:         file_color_proto_enumTypes,
+		MessageInfos:      file_color_proto_msgTypes,
+	}.Build()
+	File_color_proto = out.File
+	file_color_proto_rawDesc = nil
+	file_color_proto_goTypes = nil
+	file_color_proto_depIdxs = nil
+}
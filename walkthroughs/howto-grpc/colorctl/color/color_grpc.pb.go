@@ -0,0 +1,550 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.17.1
+// source: color.proto
+
+package color
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ColorService_GetColor_FullMethodName     = "/color.ColorService/GetColor"
+	ColorService_SetColor_FullMethodName     = "/color.ColorService/SetColor"
+	ColorService_GetFlakiness_FullMethodName = "/color.ColorService/GetFlakiness"
+	ColorService_SetFlakiness_FullMethodName = "/color.ColorService/SetFlakiness"
+	ColorService_WatchColor_FullMethodName   = "/color.ColorService/WatchColor"
+	ColorService_ReportColors_FullMethodName = "/color.ColorService/ReportColors"
+	ColorService_ColorChat_FullMethodName    = "/color.ColorService/ColorChat"
+	ColorService_SetHealth_FullMethodName    = "/color.ColorService/SetHealth"
+	ColorService_GetPayload_FullMethodName   = "/color.ColorService/GetPayload"
+	ColorService_ListColors_FullMethodName   = "/color.ColorService/ListColors"
+)
+
+// ColorServiceClient is the client API for ColorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ColorServiceClient interface {
+	GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error)
+	SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error)
+	GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error)
+	SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error)
+	// WatchColor sends the current color and then every change made through SetColor.
+	WatchColor(ctx context.Context, in *WatchColorRequest, opts ...grpc.CallOption) (ColorService_WatchColorClient, error)
+	// ReportColors accepts a stream of colors seen by the caller and summarizes them.
+	ReportColors(ctx context.Context, opts ...grpc.CallOption) (ColorService_ReportColorsClient, error)
+	// ColorChat answers every color sent by the caller with the current color.
+	ColorChat(ctx context.Context, opts ...grpc.CallOption) (ColorService_ColorChatClient, error)
+	// SetHealth changes what the gRPC health service reports for a service.
+	SetHealth(ctx context.Context, in *SetHealthRequest, opts ...grpc.CallOption) (*SetHealthResponse, error)
+	// GetPayload returns a payload of the requested size, to exercise message
+	// size limits and compression.
+	GetPayload(ctx context.Context, in *GetPayloadRequest, opts ...grpc.CallOption) (*GetPayloadResponse, error)
+	// ListColors returns the server's color catalog.
+	ListColors(ctx context.Context, in *ListColorsRequest, opts ...grpc.CallOption) (*ListColorsResponse, error)
+}
+
+type colorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewColorServiceClient(cc grpc.ClientConnInterface) ColorServiceClient {
+	return &colorServiceClient{cc}
+}
+
+func (c *colorServiceClient) GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error) {
+	out := new(GetColorResponse)
+	err := c.cc.Invoke(ctx, ColorService_GetColor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error) {
+	out := new(SetColorResponse)
+	err := c.cc.Invoke(ctx, ColorService_SetColor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error) {
+	out := new(GetFlakinessResponse)
+	err := c.cc.Invoke(ctx, ColorService_GetFlakiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error) {
+	out := new(SetFlakinessResponse)
+	err := c.cc.Invoke(ctx, ColorService_SetFlakiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) WatchColor(ctx context.Context, in *WatchColorRequest, opts ...grpc.CallOption) (ColorService_WatchColorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ColorService_ServiceDesc.Streams[0], ColorService_WatchColor_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &colorServiceWatchColorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ColorService_WatchColorClient interface {
+	Recv() (*WatchColorResponse, error)
+	grpc.ClientStream
+}
+
+type colorServiceWatchColorClient struct {
+	grpc.ClientStream
+}
+
+func (x *colorServiceWatchColorClient) Recv() (*WatchColorResponse, error) {
+	m := new(WatchColorResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *colorServiceClient) ReportColors(ctx context.Context, opts ...grpc.CallOption) (ColorService_ReportColorsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ColorService_ServiceDesc.Streams[1], ColorService_ReportColors_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &colorServiceReportColorsClient{stream}
+	return x, nil
+}
+
+type ColorService_ReportColorsClient interface {
+	Send(*ReportColorsRequest) error
+	CloseAndRecv() (*ReportColorsResponse, error)
+	grpc.ClientStream
+}
+
+type colorServiceReportColorsClient struct {
+	grpc.ClientStream
+}
+
+func (x *colorServiceReportColorsClient) Send(m *ReportColorsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *colorServiceReportColorsClient) CloseAndRecv() (*ReportColorsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ReportColorsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *colorServiceClient) ColorChat(ctx context.Context, opts ...grpc.CallOption) (ColorService_ColorChatClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ColorService_ServiceDesc.Streams[2], ColorService_ColorChat_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &colorServiceColorChatClient{stream}
+	return x, nil
+}
+
+type ColorService_ColorChatClient interface {
+	Send(*ColorChatRequest) error
+	Recv() (*ColorChatResponse, error)
+	grpc.ClientStream
+}
+
+type colorServiceColorChatClient struct {
+	grpc.ClientStream
+}
+
+func (x *colorServiceColorChatClient) Send(m *ColorChatRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *colorServiceColorChatClient) Recv() (*ColorChatResponse, error) {
+	m := new(ColorChatResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *colorServiceClient) SetHealth(ctx context.Context, in *SetHealthRequest, opts ...grpc.CallOption) (*SetHealthResponse, error) {
+	out := new(SetHealthResponse)
+	err := c.cc.Invoke(ctx, ColorService_SetHealth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) GetPayload(ctx context.Context, in *GetPayloadRequest, opts ...grpc.CallOption) (*GetPayloadResponse, error) {
+	out := new(GetPayloadResponse)
+	err := c.cc.Invoke(ctx, ColorService_GetPayload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colorServiceClient) ListColors(ctx context.Context, in *ListColorsRequest, opts ...grpc.CallOption) (*ListColorsResponse, error) {
+	out := new(ListColorsResponse)
+	err := c.cc.Invoke(ctx, ColorService_ListColors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ColorServiceServer is the server API for ColorService service.
+// All implementations must embed UnimplementedColorServiceServer
+// for forward compatibility
+type ColorServiceServer interface {
+	GetColor(context.Context, *GetColorRequest) (*GetColorResponse, error)
+	SetColor(context.Context, *SetColorRequest) (*SetColorResponse, error)
+	GetFlakiness(context.Context, *GetFlakinessRequest) (*GetFlakinessResponse, error)
+	SetFlakiness(context.Context, *SetFlakinessRequest) (*SetFlakinessResponse, error)
+	// WatchColor sends the current color and then every change made through SetColor.
+	WatchColor(*WatchColorRequest, ColorService_WatchColorServer) error
+	// ReportColors accepts a stream of colors seen by the caller and summarizes them.
+	ReportColors(ColorService_ReportColorsServer) error
+	// ColorChat answers every color sent by the caller with the current color.
+	ColorChat(ColorService_ColorChatServer) error
+	// SetHealth changes what the gRPC health service reports for a service.
+	SetHealth(context.Context, *SetHealthRequest) (*SetHealthResponse, error)
+	// GetPayload returns a payload of the requested size, to exercise message
+	// size limits and compression.
+	GetPayload(context.Context, *GetPayloadRequest) (*GetPayloadResponse, error)
+	// ListColors returns the server's color catalog.
+	ListColors(context.Context, *ListColorsRequest) (*ListColorsResponse, error)
+	mustEmbedUnimplementedColorServiceServer()
+}
+
+// UnimplementedColorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedColorServiceServer struct {
+}
+
+func (UnimplementedColorServiceServer) GetColor(context.Context, *GetColorRequest) (*GetColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetColor not implemented")
+}
+func (UnimplementedColorServiceServer) SetColor(context.Context, *SetColorRequest) (*SetColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetColor not implemented")
+}
+func (UnimplementedColorServiceServer) GetFlakiness(context.Context, *GetFlakinessRequest) (*GetFlakinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlakiness not implemented")
+}
+func (UnimplementedColorServiceServer) SetFlakiness(context.Context, *SetFlakinessRequest) (*SetFlakinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFlakiness not implemented")
+}
+func (UnimplementedColorServiceServer) WatchColor(*WatchColorRequest, ColorService_WatchColorServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchColor not implemented")
+}
+func (UnimplementedColorServiceServer) ReportColors(ColorService_ReportColorsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportColors not implemented")
+}
+func (UnimplementedColorServiceServer) ColorChat(ColorService_ColorChatServer) error {
+	return status.Errorf(codes.Unimplemented, "method ColorChat not implemented")
+}
+func (UnimplementedColorServiceServer) SetHealth(context.Context, *SetHealthRequest) (*SetHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHealth not implemented")
+}
+func (UnimplementedColorServiceServer) GetPayload(context.Context, *GetPayloadRequest) (*GetPayloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayload not implemented")
+}
+func (UnimplementedColorServiceServer) ListColors(context.Context, *ListColorsRequest) (*ListColorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListColors not implemented")
+}
+func (UnimplementedColorServiceServer) mustEmbedUnimplementedColorServiceServer() {}
+
+// UnsafeColorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ColorServiceServer will
+// result in compilation errors.
+type UnsafeColorServiceServer interface {
+	mustEmbedUnimplementedColorServiceServer()
+}
+
+func RegisterColorServiceServer(s grpc.ServiceRegistrar, srv ColorServiceServer) {
+	s.RegisterService(&ColorService_ServiceDesc, srv)
+}
+
+func _ColorService_GetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).GetColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_GetColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).GetColor(ctx, req.(*GetColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_SetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).SetColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_SetColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).SetColor(ctx, req.(*SetColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_GetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlakinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).GetFlakiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_GetFlakiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).GetFlakiness(ctx, req.(*GetFlakinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_SetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFlakinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).SetFlakiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_SetFlakiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).SetFlakiness(ctx, req.(*SetFlakinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_WatchColor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchColorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ColorServiceServer).WatchColor(m, &colorServiceWatchColorServer{stream})
+}
+
+type ColorService_WatchColorServer interface {
+	Send(*WatchColorResponse) error
+	grpc.ServerStream
+}
+
+type colorServiceWatchColorServer struct {
+	grpc.ServerStream
+}
+
+func (x *colorServiceWatchColorServer) Send(m *WatchColorResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ColorService_ReportColors_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ColorServiceServer).ReportColors(&colorServiceReportColorsServer{stream})
+}
+
+type ColorService_ReportColorsServer interface {
+	SendAndClose(*ReportColorsResponse) error
+	Recv() (*ReportColorsRequest, error)
+	grpc.ServerStream
+}
+
+type colorServiceReportColorsServer struct {
+	grpc.ServerStream
+}
+
+func (x *colorServiceReportColorsServer) SendAndClose(m *ReportColorsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *colorServiceReportColorsServer) Recv() (*ReportColorsRequest, error) {
+	m := new(ReportColorsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ColorService_ColorChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ColorServiceServer).ColorChat(&colorServiceColorChatServer{stream})
+}
+
+type ColorService_ColorChatServer interface {
+	Send(*ColorChatResponse) error
+	Recv() (*ColorChatRequest, error)
+	grpc.ServerStream
+}
+
+type colorServiceColorChatServer struct {
+	grpc.ServerStream
+}
+
+func (x *colorServiceColorChatServer) Send(m *ColorChatResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *colorServiceColorChatServer) Recv() (*ColorChatRequest, error) {
+	m := new(ColorChatRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ColorService_SetHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).SetHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_SetHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).SetHealth(ctx, req.(*SetHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_GetPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).GetPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_GetPayload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).GetPayload(ctx, req.(*GetPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColorService_ListColors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListColorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColorServiceServer).ListColors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ColorService_ListColors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColorServiceServer).ListColors(ctx, req.(*ListColorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ColorService_ServiceDesc is the grpc.ServiceDesc for ColorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ColorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "color.ColorService",
+	HandlerType: (*ColorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetColor",
+			Handler:    _ColorService_GetColor_Handler,
+		},
+		{
+			MethodName: "SetColor",
+			Handler:    _ColorService_SetColor_Handler,
+		},
+		{
+			MethodName: "GetFlakiness",
+			Handler:    _ColorService_GetFlakiness_Handler,
+		},
+		{
+			MethodName: "SetFlakiness",
+			Handler:    _ColorService_SetFlakiness_Handler,
+		},
+		{
+			MethodName: "SetHealth",
+			Handler:    _ColorService_SetHealth_Handler,
+		},
+		{
+			MethodName: "GetPayload",
+			Handler:    _ColorService_GetPayload_Handler,
+		},
+		{
+			MethodName: "ListColors",
+			Handler:    _ColorService_ListColors_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchColor",
+			Handler:       _ColorService_WatchColor_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReportColors",
+			Handler:       _ColorService_ReportColors_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ColorChat",
+			Handler:       _ColorService_ColorChat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "color.proto",
+}
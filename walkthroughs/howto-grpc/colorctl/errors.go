@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	// registers RetryInfo, ErrorInfo and the other google.rpc details so they
+	// can be decoded from a status
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// callError is a failed call along with the trailers that came with it.
+type callError struct {
+	err     error
+	trailer metadata.MD
+}
+
+func (e *callError) Error() string {
+	return e.err.Error()
+}
+
+func (e *callError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
+
+func withTrailer(err error, trailer metadata.MD) error {
+	if err == nil || len(trailer) == 0 {
+		return err
+	}
+	return &callError{err: err, trailer: trailer}
+}
+
+// describeError explains a failed call: its code and message, then one line
+// per error detail and trailer the server sent.
+func describeError(err error) string {
+	st := status.Convert(err)
+	lines := []string{fmt.Sprintf("%s: %s", st.Code(), st.Message())}
+	for _, detail := range st.Details() {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			lines = append(lines, fmt.Sprintf("  RetryInfo: retry after %v", retry.GetRetryDelay().AsDuration()))
+		} else if m, ok := detail.(proto.Message); ok {
+			lines = append(lines, fmt.Sprintf("  %s %s", m.ProtoReflect().Descriptor().Name(), prototextOf(m)))
+		} else {
+			// a type colorctl doesn't know, Details returns the error
+			lines = append(lines, fmt.Sprintf("  %v", detail))
+		}
+	}
+	if e, ok := err.(*callError); ok {
+		var keys []string
+		for key := range e.trailer {
+			if key != "content-type" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  trailer %s: %s", key, strings.Join(e.trailer[key], ",")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
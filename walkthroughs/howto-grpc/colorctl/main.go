@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const usage = `colorctl talks to ColorService directly over gRPC.
+
+Usage:
+  colorctl [flags] get
+  colorctl [flags] set [-expected-version N] <color>
+  colorctl [flags] flakiness get
+  colorctl [flags] flakiness set [-expected-version N] [field=value ...]
+  colorctl [flags] watch [-poll] [-interval 1s]
+  colorctl [flags] bench [-n 100] [-c 10] [-method GetColor]
+
+Flakiness fields are those of the Flakiness message, e.g.
+  colorctl flakiness set rate=0.5 code=UNAVAILABLE methods=GetColor,SetColor
+Repeated fields take comma separated values, and set without fields clears
+the flakiness.
+
+Flags:
+`
+
+// headers collects repeated -H key=value flags.
+type headers []string
+
+func (h *headers) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headers) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("header %q must look like key=value", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+type options struct {
+	addr       string
+	reflection bool
+	timeout    time.Duration
+	json       bool
+	headers    headers
+	tls        bool
+	ca         string
+	cert       string
+	key        string
+	serverName string
+}
+
+func getDefaultAddr() string {
+	if addr := os.Getenv("COLOR_HOST"); addr != "" {
+		return addr
+	}
+	return "localhost:8080"
+}
+
+func (o *options) transportCredentials() (grpc.DialOption, error) {
+	if !o.tls && o.ca == "" && o.cert == "" {
+		return grpc.WithInsecure(), nil
+	}
+	config := &tls.Config{ServerName: o.serverName}
+	if o.ca != "" {
+		pem, err := ioutil.ReadFile(o.ca)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.ca)
+		}
+	}
+	if o.cert != "" {
+		cert, err := tls.LoadX509KeyPair(o.cert, o.key)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+}
+
+func withHeader(ctx context.Context, header string) context.Context {
+	kv := strings.SplitN(header, "=", 2)
+	return metadata.AppendToOutgoingContext(ctx, strings.ToLower(kv[0]), kv[1])
+}
+
+// callContext applies -timeout and -H to one call.
+func (o *options) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	for _, h := range o.headers {
+		ctx = withHeader(ctx, h)
+	}
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (o *options) print(msg proto.Message, text string) {
+	if o.json {
+		b, _ := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+		fmt.Println(string(b))
+		return
+	}
+	fmt.Println(text)
+}
+
+func main() {
+	log.SetFlags(0)
+	o := &options{}
+	flag.StringVar(&o.addr, "addr", getDefaultAddr(), "ColorService address, defaults to $COLOR_HOST")
+	flag.BoolVar(&o.reflection, "reflection", false, "describe ColorService with server reflection instead of the compiled-in color.proto")
+	flag.DurationVar(&o.timeout, "timeout", 5*time.Second, "deadline for each call, 0 for none")
+	flag.BoolVar(&o.json, "json", false, "print responses as JSON")
+	flag.Var(&o.headers, "H", "metadata to send as key=value, can be repeated")
+	flag.BoolVar(&o.tls, "tls", false, "use TLS, verifying the server against the system roots unless -ca is set")
+	flag.StringVar(&o.ca, "ca", "", "CA bundle to verify the server against, implies -tls")
+	flag.StringVar(&o.cert, "cert", "", "client certificate for mTLS, implies -tls")
+	flag.StringVar(&o.key, "key", "", "client certificate key for mTLS")
+	flag.StringVar(&o.serverName, "server-name", "", "name to verify the server's certificate against, e.g. when calling through an ingress gateway")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	creds, err := o.transportCredentials()
+	if err != nil {
+		log.Fatalf("failed to load TLS config: %v", err)
+	}
+	conn, err := grpc.Dial(o.addr, creds)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	s := compiledService(conn)
+	if o.reflection {
+		rctx, rcancel := o.callContext(ctx)
+		s, err = reflectedService(rctx, conn)
+		rcancel()
+		if err != nil {
+			log.Fatalf("reflection failed: %v", err)
+		}
+	}
+
+	args := flag.Args()
+	switch args[0] {
+	case "get":
+		err = getColor(ctx, o, s)
+	case "set":
+		err = setColor(ctx, o, s, args[1:])
+	case "flakiness":
+		err = flakiness(ctx, o, s, args[1:])
+	case "watch":
+		err = watch(ctx, o, s, args[1:])
+	case "bench":
+		err = bench(ctx, o, s, args[1:])
+	default:
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() != 0 {
+			log.Fatal(describeError(err))
+		}
+		log.Fatal(err)
+	}
+}
+
+func getColor(ctx context.Context, o *options, s *colorService) error {
+	req, err := s.newRequest("GetColor")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.callContext(ctx)
+	defer cancel()
+	resp, err := s.call(ctx, "GetColor", req)
+	if err != nil {
+		return err
+	}
+	o.print(resp, withVersion(colorName(resp), resp))
+	return nil
+}
+
+// withVersion adds the version a response reports, when the server's
+// color.proto has versions.
+func withVersion(text string, resp proto.Message) string {
+	if v := getField(resp.ProtoReflect(), "version"); v.IsValid() {
+		return fmt.Sprintf("%s (version %d)", text, v.Uint())
+	}
+	return text
+}
+
+// setExpectedVersion makes a Set call conditional, when asked to.
+func setExpectedVersion(req proto.Message, expected uint64) error {
+	if expected == 0 {
+		return nil
+	}
+	if !hasField(req.ProtoReflect(), "expected_version") {
+		return errors.New("the server doesn't support -expected-version")
+	}
+	return setField(req.ProtoReflect(), "expected_version", fmt.Sprint(expected))
+}
+
+func setColor(ctx context.Context, o *options, s *colorService, args []string) error {
+	fs := flag.NewFlagSet("set", flag.ExitOnError)
+	expected := fs.Uint64("expected-version", 0, "only set the color if it is still at this version")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("usage: colorctl set [-expected-version N] <color>")
+	}
+	req, err := s.newRequest("SetColor")
+	if err != nil {
+		return err
+	}
+	if err := setColorField(req, fs.Arg(0)); err != nil {
+		return err
+	}
+	if err := setExpectedVersion(req, *expected); err != nil {
+		return err
+	}
+	ctx, cancel := o.callContext(ctx)
+	defer cancel()
+	resp, err := s.call(ctx, "SetColor", req)
+	if err != nil {
+		return err
+	}
+	o.print(resp, withVersion(fmt.Sprintf("%s -> %s", colorName(resp), colorName(req)), resp))
+	return nil
+}
+
+func flakiness(ctx context.Context, o *options, s *colorService, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: colorctl flakiness get|set")
+	}
+	switch args[0] {
+	case "get":
+		req, err := s.newRequest("GetFlakiness")
+		if err != nil {
+			return err
+		}
+		ctx, cancel := o.callContext(ctx)
+		defer cancel()
+		resp, err := s.call(ctx, "GetFlakiness", req)
+		if err != nil {
+			return err
+		}
+		o.print(resp, withVersion(prototextOf(getField(resp, "flakiness").Message().Interface()), resp))
+		return nil
+	case "set":
+		fs := flag.NewFlagSet("flakiness set", flag.ExitOnError)
+		expected := fs.Uint64("expected-version", 0, "only set the flakiness if it is still at this version")
+		fs.Parse(args[1:])
+		req, err := s.newRequest("SetFlakiness")
+		if err != nil {
+			return err
+		}
+		fd := req.Descriptor().Fields().ByName("flakiness")
+		if fd == nil {
+			return errors.New("the server's SetFlakinessRequest has no flakiness field")
+		}
+		config := req.Mutable(fd).Message()
+		for _, arg := range fs.Args() {
+			kv := strings.SplitN(arg, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("flakiness fields must look like field=value, got %q", arg)
+			}
+			if err := setField(config, kv[0], kv[1]); err != nil {
+				return err
+			}
+		}
+		if err := setExpectedVersion(req, *expected); err != nil {
+			return err
+		}
+		ctx, cancel := o.callContext(ctx)
+		defer cancel()
+		resp, err := s.call(ctx, "SetFlakiness", req)
+		if err != nil {
+			return err
+		}
+		text := fmt.Sprintf("%s -> %s", prototextOf(getField(resp, "flakiness").Message().Interface()), prototextOf(config.Interface()))
+		o.print(resp, withVersion(text, resp))
+		return nil
+	}
+	return fmt.Errorf("unknown flakiness command %q", args[0])
+}
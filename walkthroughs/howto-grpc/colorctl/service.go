@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/colorctl/color"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const serviceName = "color.ColorService"
+
+// colorService calls ColorService through its descriptor, so the same code
+// works with the color.proto compiled into colorctl and with whatever
+// version of it a server describes through reflection.
+type colorService struct {
+	conn *grpc.ClientConn
+	desc protoreflect.ServiceDescriptor
+}
+
+// compiledService uses the color.proto colorctl was built with.
+func compiledService(conn *grpc.ClientConn) *colorService {
+	return &colorService{conn: conn, desc: pb.File_color_proto.Services().ByName("ColorService")}
+}
+
+// reflectedService asks the server for its own ColorService descriptor.
+func reflectedService(ctx context.Context, conn *grpc.ClientConn) (*colorService, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+	err = stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, fmt.Errorf("reflection: %s (%s)", e.ErrorMessage, codes.Code(e.ErrorCode))
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(b, file); err != nil {
+			return nil, err
+		}
+		set.File = append(set.File, file)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, err
+	}
+	d, err := files.FindDescriptorByName(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+	return &colorService{conn: conn, desc: service}, nil
+}
+
+func (s *colorService) method(name string) (protoreflect.MethodDescriptor, error) {
+	m := s.desc.Methods().ByName(protoreflect.Name(name))
+	if m == nil {
+		return nil, fmt.Errorf("the server's %s has no %s method", serviceName, name)
+	}
+	return m, nil
+}
+
+func fullMethod(m protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", m.Parent().FullName(), m.Name())
+}
+
+// newRequest returns an empty request for the method.
+func (s *colorService) newRequest(name string) (*dynamicpb.Message, error) {
+	m, err := s.method(name)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicpb.NewMessage(m.Input()), nil
+}
+
+// call makes a unary call.
+func (s *colorService) call(ctx context.Context, name string, req *dynamicpb.Message, opts ...grpc.CallOption) (*dynamicpb.Message, error) {
+	m, err := s.method(name)
+	if err != nil {
+		return nil, err
+	}
+	resp := dynamicpb.NewMessage(m.Output())
+	var trailer metadata.MD
+	opts = append(opts, grpc.Trailer(&trailer))
+	if err := s.conn.Invoke(ctx, fullMethod(m), req, resp, opts...); err != nil {
+		return nil, withTrailer(err, trailer)
+	}
+	return resp, nil
+}
+
+// stream makes a server-streaming call and hands every response to recv.
+func (s *colorService) stream(ctx context.Context, name string, req *dynamicpb.Message, recv func(*dynamicpb.Message) error) error {
+	m, err := s.method(name)
+	if err != nil {
+		return err
+	}
+	if !m.IsStreamingServer() || m.IsStreamingClient() {
+		return fmt.Errorf("%s is not a server-streaming method", name)
+	}
+	stream, err := s.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod(m))
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		resp := dynamicpb.NewMessage(m.Output())
+		if err := stream.RecvMsg(resp); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return withTrailer(err, stream.Trailer())
+		}
+		if err := recv(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// hasField reports whether msg has a field called name, as older versions of
+// color.proto lack some of them.
+func hasField(msg protoreflect.Message, name string) bool {
+	return msg.Descriptor().Fields().ByName(protoreflect.Name(name)) != nil
+}
+
+// getField returns the value of a field, or an invalid value if there is no
+// such field.
+func getField(msg protoreflect.Message, name string) protoreflect.Value {
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return protoreflect.Value{}
+	}
+	return msg.Get(fd)
+}
+
+// enumName returns the name of an enum field's value, e.g. RED.
+func enumName(msg protoreflect.Message, name string) string {
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || fd.Enum() == nil {
+		return ""
+	}
+	n := msg.Get(fd).Enum()
+	if v := fd.Enum().Values().ByNumber(n); v != nil {
+		return string(v.Name())
+	}
+	return strconv.Itoa(int(n))
+}
+
+// colorName returns the name of the color a message reports: the name in its
+// info or its name field when the server has a color catalog, and its color
+// enum otherwise.
+func colorName(msg protoreflect.Message) string {
+	if info := getField(msg, "info"); info.IsValid() {
+		if name := getField(info.Message(), "name"); name.IsValid() && name.String() != "" {
+			return name.String()
+		}
+	}
+	if name := getField(msg, "name"); name.IsValid() && name.String() != "" {
+		return name.String()
+	}
+	return enumName(msg, "color")
+}
+
+// setColorField sets the color of a SetColor request. Colors beyond the enum
+// go by name, for servers with a color catalog.
+func setColorField(req protoreflect.Message, color string) error {
+	fd := req.Descriptor().Fields().ByName("color")
+	if fd == nil || fd.Enum() == nil || fd.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(color))) != nil || !hasField(req, "name") {
+		return setField(req, "color", color)
+	}
+	return setField(req, "name", color)
+}
+
+// setField parses value according to the type of the field called name.
+// Repeated fields take comma separated values.
+func setField(msg protoreflect.Message, name, value string) error {
+	fields := msg.Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(name))
+	if fd == nil {
+		var names []string
+		for i := 0; i < fields.Len(); i++ {
+			names = append(names, string(fields.Get(i).Name()))
+		}
+		return fmt.Errorf("%s has no field %q, it has: %s", msg.Descriptor().Name(), name, strings.Join(names, ", "))
+	}
+	if fd.IsList() {
+		list := msg.Mutable(fd).List()
+		for _, item := range strings.Split(value, ",") {
+			v, err := parseScalar(fd, strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		return nil
+	}
+	if fd.IsMap() || fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		return fmt.Errorf("field %s can't be set from the command line", name)
+	}
+	v, err := parseScalar(fd, value)
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	bad := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("invalid %s %q: %v", fd.Name(), value, err)
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.EnumKind:
+		if v := fd.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(value))); v != nil {
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return bad(fmt.Errorf("not one of the %s values", fd.Enum().Name()))
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		// status codes can be given by name, e.g. code=UNAVAILABLE
+		var code codes.Code
+		if fd.Name() == "code" && code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(value)))) == nil {
+			return protoreflect.ValueOfInt32(int32(code)), nil
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), nil
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return bad(err)
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	}
+	return bad(fmt.Errorf("unsupported type %s", fd.Kind()))
+}
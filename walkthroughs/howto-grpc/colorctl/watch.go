@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func prototextOf(m proto.Message) string {
+	return "{" + prototext.MarshalOptions{}.Format(m) + "}"
+}
+
+// watch prints the color every time it changes until interrupted. It uses
+// WatchColor when the server has it, and polls GetColor otherwise.
+func watch(ctx context.Context, o *options, s *colorService, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	poll := fs.Bool("poll", false, "poll GetColor even if the server has WatchColor")
+	interval := fs.Duration("interval", time.Second, "how often to poll GetColor")
+	fs.Parse(args)
+
+	if _, err := s.method("WatchColor"); err == nil && !*poll {
+		req, err := s.newRequest("WatchColor")
+		if err != nil {
+			return err
+		}
+		// -timeout is meant for single calls, a watch lasts until interrupted
+		for _, h := range o.headers {
+			ctx = withHeader(ctx, h)
+		}
+		err = s.stream(ctx, "WatchColor", req, func(resp *dynamicpb.Message) error {
+			o.print(resp, withVersion(fmt.Sprintf("%s %s", time.Now().Format(time.RFC3339), colorName(resp)), resp))
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	last := ""
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		req, err := s.newRequest("GetColor")
+		if err != nil {
+			return err
+		}
+		callCtx, cancel := o.callContext(ctx)
+		resp, err := s.call(callCtx, "GetColor", req)
+		cancel()
+		var text string
+		if err != nil {
+			st := status.Convert(err)
+			text = fmt.Sprintf("%s: %s", st.Code(), st.Message())
+		} else {
+			text = withVersion(colorName(resp), resp)
+		}
+		if text != last {
+			fmt.Printf("%s %s\n", time.Now().Format(time.RFC3339), text)
+			last = text
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
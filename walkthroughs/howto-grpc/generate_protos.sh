@@ -2,5 +2,15 @@
 
 set -e
 
-protoc ./color.proto --go_out=plugins=grpc:./color_client/color
-protoc ./color.proto --go_out=plugins=grpc:./color_server/color
+# The checked-in code was generated with protoc 3.17.1 and these plugins:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.31.0
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
+#   go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@v2.16.0
+for out in ./color_client/color ./color_server/color ./colorctl/color; do
+    protoc ./color.proto --go_out=$out --go-grpc_out=$out
+done
+
+# The Color Client serves ColorService as JSON over HTTP through a gateway
+# generated by protoc-gen-grpc-gateway (github.com/grpc-ecosystem/grpc-gateway/v2).
+protoc ./color.proto --grpc-gateway_out=./color_client/color \
+    --grpc-gateway_opt=grpc_api_configuration=./color_http.yaml
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the routing the xDS server hands out. It follows App Mesh: each
+// virtual service is a listener that clients dial as xds:///<name>, its routes
+// use the grpcRoute spec of App Mesh routes, and each virtual node is a
+// cluster of endpoints.
+type config struct {
+	VirtualServices []virtualService `yaml:"virtualServices"`
+	VirtualNodes    []virtualNode    `yaml:"virtualNodes"`
+}
+
+type virtualService struct {
+	Name   string  `yaml:"name"`
+	Routes []route `yaml:"routes"`
+}
+
+type virtualNode struct {
+	Name      string   `yaml:"name"`
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// route is an App Mesh grpcRoute, tried in order.
+type route struct {
+	Name        string       `yaml:"name"`
+	Match       routeMatch   `yaml:"match"`
+	Action      routeAction  `yaml:"action"`
+	RetryPolicy *retryPolicy `yaml:"retryPolicy"`
+	Timeout     *timeout     `yaml:"timeout"`
+}
+
+type routeMatch struct {
+	ServiceName string          `yaml:"serviceName"`
+	MethodName  string          `yaml:"methodName"`
+	Metadata    []metadataMatch `yaml:"metadata"`
+}
+
+type metadataMatch struct {
+	Name   string       `yaml:"name"`
+	Invert bool         `yaml:"invert"`
+	Match  *stringMatch `yaml:"match"`
+}
+
+// stringMatch matches a metadata value; without one, the key only has to be
+// present.
+type stringMatch struct {
+	Exact  string      `yaml:"exact"`
+	Prefix string      `yaml:"prefix"`
+	Suffix string      `yaml:"suffix"`
+	Regex  string      `yaml:"regex"`
+	Range  *rangeMatch `yaml:"range"`
+}
+
+type rangeMatch struct {
+	Start int64 `yaml:"start"`
+	End   int64 `yaml:"end"`
+}
+
+type routeAction struct {
+	WeightedTargets []weightedTarget `yaml:"weightedTargets"`
+}
+
+type weightedTarget struct {
+	VirtualNode string `yaml:"virtualNode"`
+	Weight      uint32 `yaml:"weight"`
+}
+
+type retryPolicy struct {
+	GrpcRetryEvents []string  `yaml:"grpcRetryEvents"`
+	MaxRetries      uint32    `yaml:"maxRetries"`
+	PerRetryTimeout *duration `yaml:"perRetryTimeout"`
+}
+
+type timeout struct {
+	PerRequest *duration `yaml:"perRequest"`
+	Idle       *duration `yaml:"idle"`
+}
+
+// duration is an App Mesh duration, e.g. {unit: ms, value: 500}.
+type duration struct {
+	Unit  string `yaml:"unit"`
+	Value int64  `yaml:"value"`
+}
+
+func (d *duration) get() (time.Duration, error) {
+	switch d.Unit {
+	case "ms":
+		return time.Duration(d.Value) * time.Millisecond, nil
+	case "s":
+		return time.Duration(d.Value) * time.Second, nil
+	}
+	return 0, fmt.Errorf("invalid duration unit %q, it must be ms or s", d.Unit)
+}
+
+// grpcRetryEvents are the App Mesh retry events, which gRPC clients support
+// too.
+var grpcRetryEvents = map[string]bool{
+	"cancelled":          true,
+	"deadline-exceeded":  true,
+	"internal":           true,
+	"resource-exhausted": true,
+	"unavailable":        true,
+}
+
+func loadConfig(name string) (*config, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &config{}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return c, nil
+}
+
+func (c *config) validate() error {
+	nodes := make(map[string]bool)
+	for _, n := range c.VirtualNodes {
+		if n.Name == "" || nodes[n.Name] {
+			return fmt.Errorf("virtual node names must be unique and not empty, got %q", n.Name)
+		}
+		nodes[n.Name] = true
+		for _, e := range n.Endpoints {
+			if _, port, err := net.SplitHostPort(e); err != nil {
+				return fmt.Errorf("virtual node %s: invalid endpoint %q: %v", n.Name, e, err)
+			} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+				return fmt.Errorf("virtual node %s: invalid port in endpoint %q", n.Name, e)
+			}
+		}
+	}
+	services := make(map[string]bool)
+	for _, s := range c.VirtualServices {
+		if s.Name == "" || services[s.Name] {
+			return fmt.Errorf("virtual service names must be unique and not empty, got %q", s.Name)
+		}
+		services[s.Name] = true
+		for _, r := range s.Routes {
+			if err := r.validate(nodes); err != nil {
+				return fmt.Errorf("virtual service %s: route %s: %v", s.Name, r.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *route) validate(nodes map[string]bool) error {
+	if r.Match.MethodName != "" && r.Match.ServiceName == "" {
+		return fmt.Errorf("methodName needs a serviceName")
+	}
+	for _, m := range r.Match.Metadata {
+		if m.Name == "" {
+			return fmt.Errorf("metadata matches need a name")
+		}
+	}
+	if len(r.Action.WeightedTargets) == 0 {
+		return fmt.Errorf("no weightedTargets")
+	}
+	var total uint32
+	for _, t := range r.Action.WeightedTargets {
+		if !nodes[t.VirtualNode] {
+			return fmt.Errorf("unknown virtual node %q", t.VirtualNode)
+		}
+		total += t.Weight
+	}
+	if total == 0 {
+		return fmt.Errorf("the weights of the weightedTargets add up to 0")
+	}
+	if p := r.RetryPolicy; p != nil {
+		for _, e := range p.GrpcRetryEvents {
+			if !grpcRetryEvents[e] {
+				return fmt.Errorf("unknown grpcRetryEvent %q", e)
+			}
+		}
+		if p.PerRetryTimeout != nil {
+			if _, err := p.PerRetryTimeout.get(); err != nil {
+				return err
+			}
+		}
+	}
+	if t := r.Timeout; t != nil {
+		for _, d := range []*duration{t.PerRequest, t.Idle} {
+			if d == nil {
+				continue
+			}
+			if _, err := d.get(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
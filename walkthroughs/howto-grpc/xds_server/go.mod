@@ -0,0 +1,12 @@
+module github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/xds_server
+
+go 1.13
+
+require (
+	github.com/envoyproxy/go-control-plane v0.11.1-0.20230524094728-9239064ad72f
+	golang.org/x/net v0.23.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98 // indirect
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
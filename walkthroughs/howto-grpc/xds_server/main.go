@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"google.golang.org/grpc"
+)
+
+// allNodes serves the same snapshot to every client, whatever node ID its
+// bootstrap gives.
+type allNodes struct{}
+
+func (allNodes) ID(node *corev3.Node) string {
+	return ""
+}
+
+// watchConfig loads the config again whenever the file changes, and hands it
+// to the clients as a new snapshot version. A config that fails to load is
+// logged and the previous one kept, but the first one has to load.
+func watchConfig(ctx context.Context, name string, cache cachev3.SnapshotCache) {
+	var modTime time.Time
+	version := 0
+	for {
+		if info, err := os.Stat(name); err != nil {
+			if version == 0 {
+				log.Fatalf("failed to load config: %v", err)
+			}
+			log.Printf("Failed to stat %s: %v", name, err)
+		} else if !info.ModTime().Equal(modTime) {
+			modTime = info.ModTime()
+			if err := loadSnapshot(ctx, name, strconv.Itoa(version+1), cache); err != nil {
+				if version == 0 {
+					log.Fatalf("failed to load config: %v", err)
+				}
+				log.Printf("Failed to load %s, keeping version %d: %v", name, version, err)
+			} else {
+				version++
+				log.Printf("Serving version %d of %s", version, name)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func loadSnapshot(ctx context.Context, name, version string, cache cachev3.SnapshotCache) error {
+	c, err := loadConfig(name)
+	if err != nil {
+		return err
+	}
+	snapshot, err := newSnapshot(version, c)
+	if err != nil {
+		return err
+	}
+	return cache.SetSnapshot(ctx, "", snapshot)
+}
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		log.Fatalf("no PORT defined")
+	}
+	configFile := os.Getenv("CONFIG_FILE")
+	if configFile == "" {
+		log.Fatalf("no CONFIG_FILE defined")
+	}
+	log.Printf("PORT is: %v", port)
+	log.Printf("CONFIG_FILE is: %v", configFile)
+
+	ctx := context.Background()
+	cache := cachev3.NewSnapshotCache(true, allNodes{}, nil)
+	go watchConfig(ctx, configFile, cache)
+
+	callbacks := serverv3.CallbackFuncs{
+		StreamRequestFunc: func(id int64, req *discoveryv3.DiscoveryRequest) error {
+			if req.ErrorDetail != nil {
+				log.Printf("Node %s rejected version %s of %s: %s", req.GetNode().GetId(), req.VersionInfo, req.TypeUrl, req.ErrorDetail.Message)
+			} else {
+				log.Printf("Node %s requested %s %v", req.GetNode().GetId(), req.TypeUrl, req.ResourceNames)
+			}
+			return nil
+		},
+	}
+	lis, err := net.Listen("tcp", "0.0.0.0:"+port)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer()
+	discoveryv3.RegisterAggregatedDiscoveryServiceServer(s, serverv3.NewServer(ctx, cache, callbacks))
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
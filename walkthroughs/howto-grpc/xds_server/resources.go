@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ads points clients back at this server for the resources a resource
+// refers to.
+func ads() *corev3.ConfigSource {
+	return &corev3.ConfigSource{
+		ResourceApiVersion:    corev3.ApiVersion_V3,
+		ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}},
+	}
+}
+
+func mustAny(msg proto.Message) *anypb.Any {
+	a, err := anypb.New(msg)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// newSnapshot turns the config into the listeners, routes, clusters and
+// endpoints gRPC's xDS resolver asks for.
+func newSnapshot(version string, c *config) (*cachev3.Snapshot, error) {
+	var listeners, routes, clusters, endpoints []types.Resource
+	for _, s := range c.VirtualServices {
+		listeners = append(listeners, newListener(s.Name))
+		routes = append(routes, newRouteConfig(s))
+	}
+	for _, n := range c.VirtualNodes {
+		clusters = append(clusters, newCluster(n.Name))
+		endpoints = append(endpoints, newLoadAssignment(n))
+	}
+	// snapshot.Consistent doesn't follow the route references of API
+	// listeners, so the references are checked by config.validate instead.
+	return cachev3.NewSnapshot(version, map[resourcev3.Type][]types.Resource{
+		resourcev3.ListenerType: listeners,
+		resourcev3.RouteType:    routes,
+		resourcev3.ClusterType:  clusters,
+		resourcev3.EndpointType: endpoints,
+	})
+}
+
+// newListener is an API listener, which is all a gRPC client needs: the HTTP
+// connection manager that names its route configuration.
+func newListener(name string) *listenerv3.Listener {
+	manager := &hcmv3.HttpConnectionManager{
+		RouteSpecifier: &hcmv3.HttpConnectionManager_Rds{
+			Rds: &hcmv3.Rds{ConfigSource: ads(), RouteConfigName: name},
+		},
+		HttpFilters: []*hcmv3.HttpFilter{{
+			Name:       "envoy.filters.http.router",
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: mustAny(&routerv3.Router{})},
+		}},
+	}
+	return &listenerv3.Listener{
+		Name:        name,
+		ApiListener: &listenerv3.ApiListener{ApiListener: mustAny(manager)},
+	}
+}
+
+func newRouteConfig(s virtualService) *routev3.RouteConfiguration {
+	host := &routev3.VirtualHost{Name: s.Name, Domains: []string{"*"}}
+	for _, r := range s.Routes {
+		host.Routes = append(host.Routes, newRoute(s.Name, r))
+	}
+	return &routev3.RouteConfiguration{Name: s.Name, VirtualHosts: []*routev3.VirtualHost{host}}
+}
+
+func newRoute(service string, r route) *routev3.Route {
+	// gRPC calls are POSTs to /<service>/<method>
+	match := &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/"}}
+	switch {
+	case r.Match.MethodName != "":
+		match.PathSpecifier = &routev3.RouteMatch_Path{Path: fmt.Sprintf("/%s/%s", r.Match.ServiceName, r.Match.MethodName)}
+	case r.Match.ServiceName != "":
+		match.PathSpecifier = &routev3.RouteMatch_Prefix{Prefix: fmt.Sprintf("/%s/", r.Match.ServiceName)}
+	}
+	for _, m := range r.Match.Metadata {
+		match.Headers = append(match.Headers, newHeaderMatcher(m))
+	}
+
+	weighted := &routev3.WeightedCluster{}
+	for _, t := range r.Action.WeightedTargets {
+		weighted.Clusters = append(weighted.Clusters, &routev3.WeightedCluster_ClusterWeight{
+			Name:   t.VirtualNode,
+			Weight: wrapperspb.UInt32(t.Weight),
+		})
+	}
+	action := &routev3.RouteAction{
+		ClusterSpecifier: &routev3.RouteAction_WeightedClusters{WeightedClusters: weighted},
+	}
+	if p := r.RetryPolicy; p != nil && len(p.GrpcRetryEvents) > 0 {
+		action.RetryPolicy = &routev3.RetryPolicy{
+			RetryOn:    strings.Join(p.GrpcRetryEvents, ","),
+			NumRetries: wrapperspb.UInt32(p.MaxRetries),
+		}
+		if p.PerRetryTimeout != nil {
+			d, _ := p.PerRetryTimeout.get()
+			action.RetryPolicy.PerTryTimeout = durationpb.New(d)
+			log.Printf("%s route %s: gRPC clients ignore perRetryTimeout", service, r.Name)
+		}
+	}
+	if t := r.Timeout; t != nil {
+		// gRPC applies max_stream_duration as the deadline of each call
+		if t.PerRequest != nil {
+			d, _ := t.PerRequest.get()
+			action.MaxStreamDuration = &routev3.RouteAction_MaxStreamDuration{MaxStreamDuration: durationpb.New(d)}
+		}
+		if t.Idle != nil {
+			log.Printf("%s route %s: gRPC clients ignore the idle timeout", service, r.Name)
+		}
+	}
+	return &routev3.Route{
+		Name:   r.Name,
+		Match:  match,
+		Action: &routev3.Route_Route{Route: action},
+	}
+}
+
+func newHeaderMatcher(m metadataMatch) *routev3.HeaderMatcher {
+	h := &routev3.HeaderMatcher{Name: m.Name, InvertMatch: m.Invert}
+	switch v := m.Match; {
+	case v == nil:
+		h.HeaderMatchSpecifier = &routev3.HeaderMatcher_PresentMatch{PresentMatch: true}
+	case v.Range != nil:
+		h.HeaderMatchSpecifier = &routev3.HeaderMatcher_RangeMatch{
+			RangeMatch: &typev3.Int64Range{Start: v.Range.Start, End: v.Range.End},
+		}
+	default:
+		s := &matcherv3.StringMatcher{}
+		switch {
+		case v.Prefix != "":
+			s.MatchPattern = &matcherv3.StringMatcher_Prefix{Prefix: v.Prefix}
+		case v.Suffix != "":
+			s.MatchPattern = &matcherv3.StringMatcher_Suffix{Suffix: v.Suffix}
+		case v.Regex != "":
+			s.MatchPattern = &matcherv3.StringMatcher_SafeRegex{SafeRegex: &matcherv3.RegexMatcher{Regex: v.Regex}}
+		default:
+			s.MatchPattern = &matcherv3.StringMatcher_Exact{Exact: v.Exact}
+		}
+		h.HeaderMatchSpecifier = &routev3.HeaderMatcher_StringMatch{StringMatch: s}
+	}
+	return h
+}
+
+func newCluster(name string) *clusterv3.Cluster {
+	return &clusterv3.Cluster{
+		Name:                 name,
+		ClusterDiscoveryType: &clusterv3.Cluster_Type{Type: clusterv3.Cluster_EDS},
+		EdsClusterConfig:     &clusterv3.Cluster_EdsClusterConfig{EdsConfig: ads()},
+		LbPolicy:             clusterv3.Cluster_ROUND_ROBIN,
+	}
+}
+
+// newLoadAssignment puts every endpoint of a virtual node in one locality.
+// gRPC ignores localities without a weight.
+func newLoadAssignment(n virtualNode) *endpointv3.ClusterLoadAssignment {
+	locality := &endpointv3.LocalityLbEndpoints{
+		Locality:            &corev3.Locality{},
+		LoadBalancingWeight: wrapperspb.UInt32(1),
+	}
+	for _, e := range n.Endpoints {
+		// validated when the config was loaded
+		host, portString, _ := net.SplitHostPort(e)
+		port, _ := strconv.ParseUint(portString, 10, 16)
+		locality.LbEndpoints = append(locality.LbEndpoints, &endpointv3.LbEndpoint{
+			HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
+				Endpoint: &endpointv3.Endpoint{
+					Address: &corev3.Address{
+						Address: &corev3.Address_SocketAddress{
+							SocketAddress: &corev3.SocketAddress{
+								Address:       host,
+								PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: uint32(port)},
+							},
+						},
+					},
+				},
+			},
+			HealthStatus: corev3.HealthStatus_HEALTHY,
+		})
+	}
+	return &endpointv3.ClusterLoadAssignment{
+		ClusterName: n.Name,
+		Endpoints:   []*endpointv3.LocalityLbEndpoints{locality},
+	}
+}
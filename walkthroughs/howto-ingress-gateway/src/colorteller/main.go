@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-xray-sdk-go/xray"
 )
@@ -40,10 +43,32 @@ func getStage() string {
 	return defaultStage
 }
 
+type colorResponse struct {
+	Color       string `json:"color"`
+	VirtualHost string `json:"virtualHost"`
+	Host        string `json:"host"`
+}
+
 type colorHandler struct{}
 func (h *colorHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	log.Println("color requested, responding with", getColor())
-	fmt.Fprint(writer, getColor())
+	vh := virtualHosts.match(request.Host)
+	log.Printf("color requested for host %s, responding with %s from virtual host %s", request.Host, vh.Color, vh.Name)
+	if vh.latency > 0 {
+		time.Sleep(vh.latency)
+	}
+	for k, v := range vh.Headers {
+		writer.Header().Set(k, v)
+	}
+	writer.Header().Set("X-Virtual-Host", vh.Name)
+
+	if strings.Contains(request.Header.Get("Accept"), "application/json") {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(vh.StatusCode)
+		json.NewEncoder(writer).Encode(colorResponse{Color: vh.Color, VirtualHost: vh.Name, Host: request.Host})
+		return
+	}
+	writer.WriteHeader(vh.StatusCode)
+	fmt.Fprint(writer, vh.Color)
 }
 
 type pingHandler struct{}
@@ -54,7 +79,12 @@ func (h *pingHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 
 func main() {
 	log.Println("starting server, listening on port " + getServerPort())
-	xraySegmentNamer := xray.NewFixedSegmentNamer(fmt.Sprintf("%s-colorteller-%s", getStage(), getColor()))
+	var err error
+	virtualHosts, err = loadVirtualHosts(getVirtualHostsConfigPath())
+	if err != nil {
+		log.Fatalf("failed to load virtual hosts: %v", err)
+	}
+	xraySegmentNamer := &virtualHostSegmentNamer{}
 	http.Handle("/", xray.Handler(xraySegmentNamer, &colorHandler{}))
 	http.Handle("/ping", xray.Handler(xraySegmentNamer, &pingHandler{}))
 	http.ListenAndServe(":"+getServerPort(), nil)
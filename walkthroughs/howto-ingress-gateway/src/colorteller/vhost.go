@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultVirtualHostName = "default"
+
+// virtualHost describes how the colorteller answers for a set of Host (or
+// HTTP/2 :authority) values. Hosts may be exact names, "*.suffix" wildcards
+// or "*" to catch everything else.
+type virtualHost struct {
+	Name       string            `json:"name"`
+	Hosts      []string          `json:"hosts"`
+	Color      string            `json:"color"`
+	StatusCode int               `json:"statusCode,omitempty"`
+	Latency    string            `json:"latency,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+
+	latency time.Duration
+}
+
+type virtualHostConfig struct {
+	VirtualHosts []*virtualHost `json:"virtualHosts"`
+}
+
+type virtualHostTable struct {
+	exact     map[string]*virtualHost
+	wildcards map[string]*virtualHost
+	fallback  *virtualHost
+}
+
+var virtualHosts *virtualHostTable
+
+func getVirtualHostsConfigPath() string {
+	return os.Getenv("VIRTUAL_HOSTS_CONFIG")
+}
+
+// loadVirtualHosts reads the virtual host config file. Without a config
+// every request is answered by a single virtual host using COLOR.
+func loadVirtualHosts(path string) (*virtualHostTable, error) {
+	table := &virtualHostTable{
+		exact:     make(map[string]*virtualHost),
+		wildcards: make(map[string]*virtualHost),
+		fallback:  &virtualHost{Name: defaultVirtualHostName, Color: getColor(), StatusCode: 200},
+	}
+	if path == "" {
+		return table, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config virtualHostConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for i, vh := range config.VirtualHosts {
+		if vh.Name == "" {
+			vh.Name = fmt.Sprintf("vhost-%d", i)
+		}
+		if vh.Color == "" {
+			return nil, fmt.Errorf("virtual host %s has no color", vh.Name)
+		}
+		if len(vh.Hosts) == 0 {
+			return nil, fmt.Errorf("virtual host %s has no hosts", vh.Name)
+		}
+		if vh.StatusCode == 0 {
+			vh.StatusCode = 200
+		}
+		// a 1xx would only be an informational response before a 200
+		if vh.StatusCode < 200 || vh.StatusCode > 599 {
+			return nil, fmt.Errorf("virtual host %s has invalid statusCode %d", vh.Name, vh.StatusCode)
+		}
+		if vh.Latency != "" {
+			vh.latency, err = time.ParseDuration(vh.Latency)
+			if err != nil {
+				return nil, fmt.Errorf("virtual host %s has invalid latency: %v", vh.Name, err)
+			}
+		}
+		for _, host := range vh.Hosts {
+			// match ignores the port of the Host header, so ports are
+			// dropped here too
+			host = strings.ToLower(host)
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+			if seen[host] {
+				return nil, fmt.Errorf("host %s is configured more than once", host)
+			}
+			seen[host] = true
+			switch {
+			case host == "*":
+				table.fallback = vh
+			case strings.HasPrefix(host, "*."):
+				table.wildcards[strings.TrimPrefix(host, "*")] = vh
+			default:
+				table.exact[host] = vh
+			}
+		}
+	}
+	return table, nil
+}
+
+// match finds the virtual host for a Host header value, preferring exact
+// names, then the longest matching wildcard suffix, then the fallback.
+func (t *virtualHostTable) match(host string) *virtualHost {
+	host = strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if vh, ok := t.exact[host]; ok {
+		return vh
+	}
+
+	var best *virtualHost
+	bestLen := 0
+	for suffix, vh := range t.wildcards {
+		if strings.HasSuffix(host, suffix) && len(suffix) > bestLen {
+			best = vh
+			bestLen = len(suffix)
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return t.fallback
+}
+
+// virtualHostSegmentNamer names X-Ray segments after the color of the virtual
+// host that answers, rather than after COLOR.
+type virtualHostSegmentNamer struct{}
+
+func (n *virtualHostSegmentNamer) Name(host string) string {
+	return fmt.Sprintf("%s-colorteller-%s", getStage(), virtualHosts.match(host).Color)
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCPUDuration = 100 * time.Millisecond
+const defaultHoldDuration = 30 * time.Second
+const maxCPUDuration = 30 * time.Second
+const maxHoldDuration = 30 * time.Minute
+const defaultMaxMemoryMB = 1024
+
+// maxHeldGoroutines and maxHeldConnections limit what all calls hold together.
+// A connection to this server uses two file descriptors, one for each end.
+const maxHeldGoroutines = 100000
+const maxHeldConnections = 1000
+
+// loadState tracks the load currently being generated so that /load/status
+// can report it while autoscalers react.
+type loadState struct {
+	cpuBurners  int64
+	goroutines  int64
+	connections int64
+
+	mutex    sync.Mutex
+	memoryMB int
+	blocks   map[int][]byte
+	nextID   int
+}
+
+var load = &loadState{blocks: make(map[int][]byte)}
+
+// getLoadMaxMemoryMB is how much memory /load/memory may hold across all
+// calls, so that repeated or concurrent calls can't run the task out of memory.
+func getLoadMaxMemoryMB() int {
+	if limit, err := strconv.Atoi(os.Getenv("LOAD_MAX_MEMORY_MB")); err == nil && limit > 0 {
+		return limit
+	}
+
+	return defaultMaxMemoryMB
+}
+
+// getLoadConnectionTargets is the addresses other than this server that
+// /load/connections may dial, from a comma separated LOAD_CONNECTION_TARGETS.
+func getLoadConnectionTargets() []string {
+	var targets []string
+	for _, target := range strings.Split(os.Getenv("LOAD_CONNECTION_TARGETS"), ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// reserve adds n to held unless that would take it over limit, so concurrent
+// calls can't overshoot the limit together.
+func reserve(held *int64, n, limit int64) bool {
+	for {
+		current := atomic.LoadInt64(held)
+		if current+n > limit {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(held, current, current+n) {
+			return true
+		}
+	}
+}
+
+func getQueryDuration(request *http.Request, name string, fallback, max time.Duration) (time.Duration, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+	if duration <= 0 || duration > max {
+		return 0, fmt.Errorf("%s must be between 0 and %s", name, max)
+	}
+	return duration, nil
+}
+
+func getQueryInt(request *http.Request, name string, fallback, max int) (int, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+	if n < 1 || n > max {
+		return 0, fmt.Errorf("%s must be between 1 and %d", name, max)
+	}
+	return n, nil
+}
+
+// burnCPU keeps one core busy until the deadline passes.
+func burnCPU(duration time.Duration) {
+	deadline := time.Now().Add(duration)
+	x := 0
+	for time.Now().Before(deadline) {
+		for i := 0; i < 100000; i++ {
+			x += i * i
+		}
+	}
+	_ = x
+}
+
+type cpuLoadHandler struct{}
+
+// ServeHTTP burns CPU on "cores" goroutines for "duration" before answering
+// with the color, e.g. /load/cpu?duration=250ms&cores=2
+func (h *cpuLoadHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	duration, err := getQueryDuration(request, "duration", defaultCPUDuration, maxCPUDuration)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cores, err := getQueryInt(request, "cores", 1, runtime.NumCPU())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("cpu load requested, burning %d core(s) for %s", cores, duration)
+	var wg sync.WaitGroup
+	for i := 0; i < cores; i++ {
+		wg.Add(1)
+		atomic.AddInt64(&load.cpuBurners, 1)
+		go func() {
+			defer wg.Done()
+			defer atomic.AddInt64(&load.cpuBurners, -1)
+			burnCPU(duration)
+		}()
+	}
+	wg.Wait()
+	fmt.Fprint(writer, getColor())
+}
+
+type memoryLoadHandler struct{}
+
+// ServeHTTP allocates "mb" megabytes and holds them for "hold" in the
+// background, e.g. /load/memory?mb=256&hold=5m
+func (h *memoryLoadHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	maxMemoryMB := getLoadMaxMemoryMB()
+	size, err := getQueryInt(request, "mb", 64, maxMemoryMB)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hold, err := getQueryDuration(request, "hold", defaultHoldDuration, maxHoldDuration)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// reserve the memory before allocating it so concurrent calls can't
+	// overshoot the limit together
+	load.mutex.Lock()
+	if load.memoryMB+size > maxMemoryMB {
+		held := load.memoryMB
+		load.mutex.Unlock()
+		http.Error(writer, fmt.Sprintf("already holding %dMB, %dMB more would exceed the %dMB limit", held, size, maxMemoryMB), http.StatusServiceUnavailable)
+		return
+	}
+	id := load.nextID
+	load.nextID++
+	load.memoryMB += size
+	load.mutex.Unlock()
+
+	block := make([]byte, size<<20)
+	// touch every page so the allocation is resident and not just reserved
+	for i := 0; i < len(block); i += 4096 {
+		block[i] = 1
+	}
+
+	load.mutex.Lock()
+	load.blocks[id] = block
+	load.mutex.Unlock()
+
+	log.Printf("memory load requested, holding %dMB for %s", size, hold)
+	time.AfterFunc(hold, func() {
+		load.mutex.Lock()
+		delete(load.blocks, id)
+		load.memoryMB -= size
+		load.mutex.Unlock()
+		runtime.GC()
+		log.Printf("released %dMB of held memory", size)
+	})
+	fmt.Fprint(writer, getColor())
+}
+
+type goroutineLoadHandler struct{}
+
+// ServeHTTP parks "count" goroutines for "hold", e.g.
+// /load/goroutines?count=10000&hold=1m
+func (h *goroutineLoadHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	count, err := getQueryInt(request, "count", 1000, maxHeldGoroutines)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hold, err := getQueryDuration(request, "hold", defaultHoldDuration, maxHoldDuration)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !reserve(&load.goroutines, int64(count), maxHeldGoroutines) {
+		http.Error(writer, fmt.Sprintf("already holding %d goroutine(s), %d more would exceed the limit of %d", atomic.LoadInt64(&load.goroutines), count, maxHeldGoroutines), http.StatusServiceUnavailable)
+		return
+	}
+
+	log.Printf("goroutine load requested, holding %d goroutine(s) for %s", count, hold)
+	release := time.After(hold)
+	done := make(chan struct{})
+	for i := 0; i < count; i++ {
+		go func() {
+			defer atomic.AddInt64(&load.goroutines, -1)
+			<-done
+		}()
+	}
+	go func() {
+		<-release
+		close(done)
+	}()
+	fmt.Fprint(writer, getColor())
+}
+
+type connectionLoadHandler struct{}
+
+// ServeHTTP opens "count" TCP connections to "target" (this server by
+// default) and keeps them open for "hold", e.g.
+// /load/connections?count=100&hold=1m&target=blue.howto-k8s-fargate:8080
+// Other targets must be listed in LOAD_CONNECTION_TARGETS, so the app can't
+// be used to reach arbitrary addresses.
+func (h *connectionLoadHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	count, err := getQueryInt(request, "count", 10, maxHeldConnections)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hold, err := getQueryDuration(request, "hold", defaultHoldDuration, maxHoldDuration)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	target := request.URL.Query().Get("target")
+	if target == "" {
+		target = "localhost:" + getServerPort()
+	} else if !isAllowedTarget(target) {
+		http.Error(writer, fmt.Sprintf("target %q is not in LOAD_CONNECTION_TARGETS", target), http.StatusForbidden)
+		return
+	}
+
+	if !reserve(&load.connections, int64(count), maxHeldConnections) {
+		http.Error(writer, fmt.Sprintf("already holding %d connection(s), %d more would exceed the limit of %d", atomic.LoadInt64(&load.connections), count, maxHeldConnections), http.StatusServiceUnavailable)
+		return
+	}
+
+	log.Printf("connection load requested, holding %d connection(s) to %s for %s", count, target, hold)
+	var conns []net.Conn
+	for i := 0; i < count; i++ {
+		conn, err := net.DialTimeout("tcp", target, 5*time.Second)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			atomic.AddInt64(&load.connections, -int64(count))
+			http.Error(writer, fmt.Sprintf("dial %s failed after %d connection(s): %v", target, len(conns), err), http.StatusBadGateway)
+			return
+		}
+		conns = append(conns, conn)
+	}
+	time.AfterFunc(hold, func() {
+		for _, c := range conns {
+			c.Close()
+		}
+		atomic.AddInt64(&load.connections, -int64(len(conns)))
+		log.Printf("closed %d held connection(s) to %s", len(conns), target)
+	})
+	fmt.Fprint(writer, getColor())
+}
+
+// isAllowedTarget reports whether /load/connections may dial target.
+func isAllowedTarget(target string) bool {
+	if target == "localhost:"+getServerPort() {
+		return true
+	}
+	for _, allowed := range getLoadConnectionTargets() {
+		if target == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+type loadStatus struct {
+	Color           string `json:"color"`
+	CPUBurners      int64  `json:"cpuBurners"`
+	HeldMemoryMB    int    `json:"heldMemoryMB"`
+	HeldGoroutines  int64  `json:"heldGoroutines"`
+	HeldConnections int64  `json:"heldConnections"`
+	NumGoroutine    int    `json:"numGoroutine"`
+	NumCPU          int    `json:"numCPU"`
+	HeapAllocMB     uint64 `json:"heapAllocMB"`
+	SysMB           uint64 `json:"sysMB"`
+}
+
+type loadStatusHandler struct{}
+
+func (h *loadStatusHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	load.mutex.Lock()
+	heldMemoryMB := load.memoryMB
+	load.mutex.Unlock()
+
+	status := loadStatus{
+		Color:           getColor(),
+		CPUBurners:      atomic.LoadInt64(&load.cpuBurners),
+		HeldMemoryMB:    heldMemoryMB,
+		HeldGoroutines:  atomic.LoadInt64(&load.goroutines),
+		HeldConnections: atomic.LoadInt64(&load.connections),
+		NumGoroutine:    runtime.NumGoroutine(),
+		NumCPU:          runtime.NumCPU(),
+		HeapAllocMB:     memStats.HeapAlloc >> 20,
+		SysMB:           memStats.Sys >> 20,
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(status)
+}
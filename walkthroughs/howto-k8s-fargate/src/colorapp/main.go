@@ -68,5 +68,10 @@ func main() {
 	xraySegmentNamer := xray.NewFixedSegmentNamer(getXRAYAppName())
 	http.Handle("/", xray.Handler(xraySegmentNamer, &colorHandler{}))
 	http.Handle("/ping", xray.Handler(xraySegmentNamer, &pingHandler{}))
+	http.Handle("/load/cpu", xray.Handler(xraySegmentNamer, &cpuLoadHandler{}))
+	http.Handle("/load/memory", xray.Handler(xraySegmentNamer, &memoryLoadHandler{}))
+	http.Handle("/load/goroutines", xray.Handler(xraySegmentNamer, &goroutineLoadHandler{}))
+	http.Handle("/load/connections", xray.Handler(xraySegmentNamer, &connectionLoadHandler{}))
+	http.Handle("/load/status", xray.Handler(xraySegmentNamer, &loadStatusHandler{}))
 	http.ListenAndServe(":"+getServerPort(), nil)
 }
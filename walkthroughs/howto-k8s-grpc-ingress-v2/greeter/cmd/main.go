@@ -1,29 +1,168 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"greeter/config"
 	"greeter/input"
 	"greeter/server"
+	"greeter/web"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/admin"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-var (
-	port = 9111
-)
+// registerGreeter registers the greeter services the config asks for, and
+// returns their names.
+func registerGreeter(s *grpc.Server, c *config.Config, identity *server.Identity) []string {
+	var services []string
+	if c.V1 {
+		input.RegisterHelloServer(s, &server.HelloServer{Identity: identity, EchoMetadata: c.EchoMetadata})
+		services = append(services, "Hello")
+	}
+	if c.V2 {
+		input.RegisterGreeterServer(s, &server.GreeterServer{Identity: identity})
+		services = append(services, "greeter.v2.Greeter")
+	}
+	return services
+}
+
+// gracefulStop lets the calls in flight finish, but no longer than timeout.
+// webServer and webGrpcServer are nil when gRPC-Web is off.
+func gracefulStop(s *grpc.Server, webServer *http.Server, webGrpcServer *grpc.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	if webServer != nil {
+		if err := webServer.Shutdown(ctx); err != nil {
+			log.Printf("gRPC-Web calls still in flight after %v, stopping\n", timeout)
+		}
+		webGrpcServer.Stop()
+	}
+	select {
+	case <-stopped:
+		log.Printf("Stopped gracefully\n")
+	case <-ctx.Done():
+		log.Printf("Calls still in flight after %v, stopping\n", timeout)
+		s.Stop()
+	}
+}
 
 func main() {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	c, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	var opts []grpc.ServerOption
+	tlsConfig, err := c.TLSConfig()
 	if err != nil {
-		log.Fatal(err)
-	}
-	log.Printf("Initializing gRPC server on port %d\n", port)
-	log.Printf("Listening on %d\n", port)
-	grpcServer := grpc.NewServer()
-	input.RegisterHelloServer(grpcServer, &server.HelloServer{})
-	reflection.Register(grpcServer)
-	grpcServer.Serve(lis)
+		log.Fatalf("failed to load TLS files: %v", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	log.Printf("TLS is on: %v, client certificates are required: %v\n", tlsConfig != nil, c.TLSCA != "")
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", c.Port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	log.Printf("Initializing gRPC server on port %d\n", c.Port)
+	identity := &server.Identity{Pod: c.Pod, Version: c.Version}
+	interceptors := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(identity.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(identity.StreamInterceptor),
+	}
+	grpcServer := grpc.NewServer(append(opts, interceptors...)...)
+	services := registerGreeter(grpcServer, c, identity)
+	log.Printf("Serving %v as %s version %q\n", services, c.Pod, c.Version)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	if c.Reflection {
+		reflection.Register(grpcServer)
+	}
+	if c.Admin {
+		cleanup, err := admin.Register(grpcServer)
+		if err != nil {
+			log.Fatalf("failed to register the admin services: %v", err)
+		}
+		defer cleanup()
+	}
+	log.Printf("Reflection is %v, admin services are %v\n", c.Reflection, c.Admin)
+	healthAdmin := server.NewHealthAdmin(healthServer, services...)
+
+	var webServer *http.Server
+	var webGrpcServer *grpc.Server
+	if c.GrpcWebPort != 0 {
+		// a server of its own, see web.NewHandler
+		webGrpcServer = grpc.NewServer(interceptors...)
+		registerGreeter(webGrpcServer, c, identity)
+		healthpb.RegisterHealthServer(webGrpcServer, healthServer)
+		webServer = &http.Server{
+			Handler:   web.NewHandler(webGrpcServer, c.CORSAllowedOrigins, c.CORSAllowedHeaders),
+			TLSConfig: tlsConfig,
+		}
+		var webLis net.Listener
+		if c.GrpcWebPort == c.Port {
+			lis, webLis = web.Split(lis)
+		} else if webLis, err = net.Listen("tcp", fmt.Sprintf(":%d", c.GrpcWebPort)); err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		log.Printf("Serving gRPC-Web on port %d to origins %v\n", c.GrpcWebPort, c.CORSAllowedOrigins)
+		go func() {
+			var err error
+			if tlsConfig != nil {
+				err = webServer.ServeTLS(webLis, "", "")
+			} else {
+				err = webServer.Serve(webLis)
+			}
+			if err != http.ErrServerClosed {
+				log.Fatalf("failed to serve gRPC-Web: %v", err)
+			}
+		}()
+	}
+
+	if c.AdminPort != 0 {
+		http.Handle("/health", healthAdmin)
+		go func() {
+			log.Printf("Serving the health admin endpoint on port %d\n", c.AdminPort)
+			log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", c.AdminPort), nil))
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		sig := <-signals
+		log.Printf("Received %v, draining for up to %v\n", sig, c.ShutdownDrainDelay+c.ShutdownTimeout)
+		healthAdmin.Shutdown()
+		// keep serving until health checks have seen NOT_SERVING and
+		// stopped sending new calls
+		time.Sleep(c.ShutdownDrainDelay)
+		gracefulStop(grpcServer, webServer, webGrpcServer, c.ShutdownTimeout)
+	}()
+
+	log.Printf("Listening on %d\n", c.Port)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+	// Serve returns as soon as the gRPC calls are done, gRPC-Web may not be
+	<-drained
 }
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is how the greeter is set up, read from the environment so the same
+// image can be deployed anywhere.
+type Config struct {
+	// Port is the gRPC port, PORT, 9111 by default.
+	Port int
+	// AdminPort serves the HTTP endpoint that changes the health status,
+	// ADMIN_PORT. It is off by default.
+	AdminPort int
+	// TLSCert and TLSKey serve TLS, and TLSCA requires client certificates
+	// signed by it, from TLS_CERT, TLS_KEY and TLS_CA.
+	TLSCert, TLSKey, TLSCA string
+	// ShutdownDrainDelay is how long SIGTERM keeps serving after reporting
+	// NOT_SERVING, so that health checks see it before the listeners close,
+	// SHUTDOWN_DRAIN_DELAY, 5s by default.
+	ShutdownDrainDelay time.Duration
+	// ShutdownTimeout is how long SIGTERM then waits for calls in flight
+	// before cutting them off, SHUTDOWN_TIMEOUT, 20s by default.
+	ShutdownTimeout time.Duration
+	// V1 and V2 are whether the Hello and greeter.v2.Greeter services are
+	// served, from SERVICES, a comma separated list of v1 and v2, both by
+	// default.
+	V1, V2 bool
+	// Pod and Version identify the greeter in its responses, from POD_NAME,
+	// the host name without it, and VERSION.
+	Pod, Version string
+	// EchoMetadata makes SayHello return the request metadata and the
+	// identity for every request, from ECHO_METADATA.
+	EchoMetadata bool
+	// GrpcWebPort serves gRPC-Web, on the gRPC port itself when it is
+	// Port, from GRPC_WEB_PORT. It is off by default.
+	GrpcWebPort int
+	// CORSAllowedOrigins are the origins browsers may make gRPC-Web calls
+	// from, "*" for any, and CORSAllowedHeaders the headers they may send on
+	// top of those gRPC-Web needs, comma separated in CORS_ALLOWED_ORIGINS
+	// and CORS_ALLOWED_HEADERS.
+	CORSAllowedOrigins, CORSAllowedHeaders []string
+	// Reflection registers server reflection, GRPC_REFLECTION, on by
+	// default, and Admin the channelz admin service that grpcdebug reads,
+	// GRPC_ADMIN.
+	Reflection, Admin bool
+}
+
+func getBool(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return b, nil
+}
+
+func getList(name string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getPort(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return port, nil
+}
+
+// Load reads the config from the environment.
+func Load() (*Config, error) {
+	c := &Config{
+		TLSCert:            os.Getenv("TLS_CERT"),
+		TLSKey:             os.Getenv("TLS_KEY"),
+		TLSCA:              os.Getenv("TLS_CA"),
+		ShutdownDrainDelay: 5 * time.Second,
+		ShutdownTimeout:    20 * time.Second,
+		Pod:                os.Getenv("POD_NAME"),
+		Version:            os.Getenv("VERSION"),
+		CORSAllowedOrigins: getList("CORS_ALLOWED_ORIGINS"),
+		CORSAllowedHeaders: getList("CORS_ALLOWED_HEADERS"),
+	}
+	var err error
+	if c.Pod == "" {
+		if c.Pod, err = os.Hostname(); err != nil {
+			return nil, err
+		}
+	}
+	if c.EchoMetadata, err = getBool("ECHO_METADATA", false); err != nil {
+		return nil, err
+	}
+	if c.Reflection, err = getBool("GRPC_REFLECTION", true); err != nil {
+		return nil, err
+	}
+	if c.Admin, err = getBool("GRPC_ADMIN", false); err != nil {
+		return nil, err
+	}
+	services := os.Getenv("SERVICES")
+	if services == "" {
+		services = "v1,v2"
+	}
+	for _, service := range strings.Split(services, ",") {
+		switch strings.TrimSpace(service) {
+		case "v1":
+			c.V1 = true
+		case "v2":
+			c.V2 = true
+		default:
+			return nil, fmt.Errorf("invalid SERVICES %q, it must list v1, v2 or both", services)
+		}
+	}
+	if c.Port, err = getPort("PORT", 9111); err != nil {
+		return nil, err
+	}
+	if c.AdminPort, err = getPort("ADMIN_PORT", 0); err != nil {
+		return nil, err
+	}
+	if c.GrpcWebPort, err = getPort("GRPC_WEB_PORT", 0); err != nil {
+		return nil, err
+	}
+	if value := os.Getenv("SHUTDOWN_DRAIN_DELAY"); value != "" {
+		if c.ShutdownDrainDelay, err = time.ParseDuration(value); err != nil || c.ShutdownDrainDelay < 0 {
+			return nil, fmt.Errorf("invalid SHUTDOWN_DRAIN_DELAY %q", value)
+		}
+	}
+	if value := os.Getenv("SHUTDOWN_TIMEOUT"); value != "" {
+		if c.ShutdownTimeout, err = time.ParseDuration(value); err != nil || c.ShutdownTimeout < 0 {
+			return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q", value)
+		}
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return nil, fmt.Errorf("TLS_CERT and TLS_KEY must be set together")
+	}
+	if c.TLSCA != "" && c.TLSCert == "" {
+		return nil, fmt.Errorf("TLS_CA needs TLS_CERT and TLS_KEY")
+	}
+	if c.GrpcWebPort == c.Port && c.TLSCert != "" {
+		// telling gRPC from gRPC-Web needs a look at the plaintext
+		return nil, fmt.Errorf("GRPC_WEB_PORT can only be PORT without TLS")
+	}
+	return c, nil
+}
+
+// TLSConfig is the TLS of the gRPC and gRPC-Web ports. It returns nil when
+// TLS is off.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	if c.TLSCert == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if c.TLSCA != "" {
+		pem, err := ioutil.ReadFile(c.TLSCA)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.TLSCA)
+		}
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
@@ -0,0 +1,8 @@
+#!/usr/bin/env bash
+
+set -e
+
+# The checked-in code was generated with protoc 3.17.1 and these plugins:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.31.0
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
+protoc -I . --go_out=. --go-grpc_out=. input/input.proto input/greeter_v2.proto
@@ -3,6 +3,10 @@ module greeter
 go 1.15
 
 require (
+	github.com/desertbit/timer v1.0.1 // indirect
+	github.com/gorilla/websocket v1.5.3 // indirect
+	github.com/improbable-eng/grpc-web v0.13.0
+	github.com/rs/cors v1.11.1 // indirect
 	golang.org/x/net v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98 // indirect
 	google.golang.org/grpc v1.56.3
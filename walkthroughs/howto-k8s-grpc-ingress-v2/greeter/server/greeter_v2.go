@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"greeter/input"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const greeterV2 = "greeter.v2.Greeter"
+
+const (
+	defaultStreamCount    = 5
+	maxStreamCount        = 100
+	defaultStreamInterval = 500 * time.Millisecond
+	maxStreamInterval     = 10 * time.Second
+)
+
+// greetings are the localized forms of "Hello %s", by language.
+var greetings = map[string]string{
+	"de": "Hallo %s",
+	"en": "Hello %s",
+	"es": "Hola %s",
+	"fr": "Bonjour %s",
+	"hi": "नमस्ते %s",
+	"it": "Ciao %s",
+	"ja": "こんにちは %sさん",
+	"pt": "Olá %s",
+	"zh": "你好 %s",
+}
+
+// GreeterServer is greeter.v2.Greeter.
+type GreeterServer struct {
+	input.UnimplementedGreeterServer
+	Identity *Identity
+}
+
+func (s *GreeterServer) SayHello(ctx context.Context, req *input.HelloRequest) (*input.HelloReply, error) {
+	log.Printf("Received v2 request for: %s\n", req.GetUser())
+	return &input.HelloReply{
+		Message:  "Hello " + req.GetUser(),
+		Identity: s.Identity.forService(greeterV2),
+	}, nil
+}
+
+func (s *GreeterServer) SayHelloStream(req *input.HelloStreamRequest, stream input.Greeter_SayHelloStreamServer) error {
+	count := req.GetCount()
+	if count == 0 {
+		count = defaultStreamCount
+	}
+	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
+	if interval == 0 {
+		interval = defaultStreamInterval
+	}
+	if count > maxStreamCount || interval > maxStreamInterval {
+		return status.Errorf(codes.InvalidArgument, "count must be at most %d and interval_ms at most %d", maxStreamCount, maxStreamInterval.Milliseconds())
+	}
+	log.Printf("Received v2 stream request for: %s, %d replies every %v\n", req.GetUser(), count, interval)
+	for i := uint32(1); i <= count; i++ {
+		if i > 1 {
+			select {
+			case <-stream.Context().Done():
+				return status.FromContextError(stream.Context().Err()).Err()
+			case <-time.After(interval):
+			}
+		}
+		err := stream.Send(&input.HelloReply{
+			Message:  "Hello " + req.GetUser(),
+			Identity: s.Identity.forService(greeterV2),
+			Sequence: i,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findGreeting picks the greeting for a locale such as es-MX, trying the
+// language alone if the locale has no greeting of its own.
+func findGreeting(locale string) (string, string, bool) {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if greeting, ok := greetings[strings.ToLower(locale)]; ok {
+		return greeting, locale, true
+	}
+	language := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+	greeting, ok := greetings[language]
+	return greeting, language, ok
+}
+
+func (s *GreeterServer) SayHelloLocalized(ctx context.Context, req *input.LocalizedHelloRequest) (*input.LocalizedHelloReply, error) {
+	log.Printf("Received v2 localized request for: %s in %q\n", req.GetUser(), req.GetLocale())
+	greeting, locale, ok := findGreeting(req.GetLocale())
+	if !ok {
+		greeting, locale, ok = findGreeting(req.GetFallbackLocale())
+	}
+	if !ok {
+		greeting, locale = greetings["en"], "en"
+	}
+	return &input.LocalizedHelloReply{
+		Message:  fmt.Sprintf(greeting, req.GetUser()),
+		Locale:   locale,
+		Identity: s.Identity.forService(greeterV2),
+	}, nil
+}
@@ -4,11 +4,50 @@ import (
 	"context"
 	"greeter/input"
 	"log"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
 )
 
-type HelloServer struct{}
+// redacted are metadata keys whose values are credentials, which are echoed
+// only as present.
+var redacted = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+}
+
+type HelloServer struct {
+	input.UnimplementedHelloServer
+	Identity *Identity
+	// EchoMetadata echoes the metadata and identity for every request, not
+	// only the ones that ask for it.
+	EchoMetadata bool
+}
+
+// echoMetadata lists the metadata of a call, :authority, user-agent,
+// x-forwarded-client-cert and whatever the client and proxies added, sorted by
+// key.
+func echoMetadata(ctx context.Context) []*input.Metadata {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var echoed []*input.Metadata
+	for key, values := range md {
+		if redacted[strings.ToLower(key)] {
+			values = []string{"REDACTED"}
+		}
+		echoed = append(echoed, &input.Metadata{Key: key, Values: values})
+	}
+	sort.Slice(echoed, func(i, j int) bool { return echoed[i].Key < echoed[j].Key })
+	return echoed
+}
 
 func (s *HelloServer) SayHello(ctx context.Context, name *input.Name) (*input.Result, error) {
 	log.Printf("Received request for: %s\n", name.GetUser())
-	return &input.Result{Output: "Hello " + name.GetUser()}, nil
+	result := &input.Result{Output: "Hello " + name.GetUser()}
+	if name.GetEcho() || s.EchoMetadata {
+		result.Metadata = echoMetadata(ctx)
+		result.Pod = s.Identity.Pod
+		result.Version = s.Identity.Version
+	}
+	return result, nil
 }
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthAdmin lets an operator take the greeter out of service, or put it
+// back, without restarting it. It sets the status the gRPC health service
+// reports, which is what App Mesh health checks ask for.
+type HealthAdmin struct {
+	health *health.Server
+
+	mutex        sync.Mutex
+	statuses     map[string]healthpb.HealthCheckResponse_ServingStatus
+	shuttingDown bool
+}
+
+// NewHealthAdmin reports every one of services, and the server as a whole,
+// as SERVING.
+func NewHealthAdmin(h *health.Server, services ...string) *HealthAdmin {
+	a := &HealthAdmin{health: h, statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus)}
+	for _, service := range append([]string{""}, services...) {
+		a.SetStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	return a
+}
+
+// SetStatus sets the status of service, and reports false without changing it
+// once Shutdown has started.
+func (a *HealthAdmin) SetStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.shuttingDown {
+		return false
+	}
+	a.statuses[service] = status
+	a.health.SetServingStatus(service, status)
+	log.Printf("Health of %q is now %s\n", service, status)
+	return true
+}
+
+// Shutdown reports every service as NOT_SERVING for good, so that health
+// checks stop sending calls while the server drains.
+func (a *HealthAdmin) Shutdown() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.shuttingDown = true
+	for service := range a.statuses {
+		a.statuses[service] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	a.health.Shutdown()
+	log.Printf("Health is now NOT_SERVING for shutdown\n")
+}
+
+// ServeHTTP shows the status of every service on GET, and on POST sets the
+// status of the "service" query parameter, the whole server when it is
+// omitted, to the "status" one, e.g. POST /health?status=NOT_SERVING. Once
+// Shutdown has started the status can no longer be changed.
+func (a *HealthAdmin) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		value := strings.ToUpper(req.URL.Query().Get("status"))
+		status := healthpb.HealthCheckResponse_ServingStatus(healthpb.HealthCheckResponse_ServingStatus_value[value])
+		if status != healthpb.HealthCheckResponse_SERVING && status != healthpb.HealthCheckResponse_NOT_SERVING {
+			http.Error(w, fmt.Sprintf("invalid status %q, it must be SERVING or NOT_SERVING", value), http.StatusBadRequest)
+			return
+		}
+		if !a.SetStatus(req.URL.Query().Get("service"), status) {
+			http.Error(w, "the greeter is shutting down", http.StatusConflict)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	a.mutex.Lock()
+	statuses := make(map[string]string, len(a.statuses))
+	for service, status := range a.statuses {
+		statuses[service] = status.String()
+	}
+	a.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statuses)
+}
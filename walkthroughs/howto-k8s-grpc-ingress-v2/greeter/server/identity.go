@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"greeter/input"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Identity is which greeter answered a call. Every response carries it in the
+// x-greeter-pod and x-greeter-version headers, and the greeter.v2 replies in
+// their identity field too.
+type Identity struct {
+	Pod     string
+	Version string
+}
+
+func (id *Identity) forService(service string) *input.ServerIdentity {
+	return &input.ServerIdentity{Pod: id.Pod, Version: id.Version, Service: service}
+}
+
+func (id *Identity) header() metadata.MD {
+	return metadata.Pairs("x-greeter-pod", id.Pod, "x-greeter-version", id.Version)
+}
+
+// UnaryInterceptor adds the identity headers to unary responses.
+func (id *Identity) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	grpc.SetHeader(ctx, id.header())
+	return handler(ctx, req)
+}
+
+// StreamInterceptor adds the identity headers to streamed responses.
+func (id *Identity) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ss.SetHeader(id.header())
+	return handler(srv, ss)
+}
@@ -0,0 +1,140 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// http2Preface starts every HTTP/2 connection, which native gRPC always uses.
+// Browsers send gRPC-Web over plaintext as HTTP/1.1.
+var http2Preface = []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+
+const sniffTimeout = 10 * time.Second
+
+// Split shares one plaintext listener between native gRPC and gRPC-Web by
+// looking at how each connection starts: HTTP/2 connections are accepted from
+// grpcLis and all others from webLis. lis is closed once both are.
+func Split(lis net.Listener) (grpcLis, webLis net.Listener) {
+	s := &splitter{lis: lis}
+	s.grpc = s.newSide()
+	s.web = s.newSide()
+	go s.serve()
+	return s.grpc, s.web
+}
+
+type splitter struct {
+	lis       net.Listener
+	grpc, web *side
+
+	mutex  sync.Mutex
+	closed int
+}
+
+func (s *splitter) newSide() *side {
+	return &side{splitter: s, conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+func (s *splitter) serve() {
+	for {
+		conn, err := s.lis.Accept()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			s.grpc.fail(err)
+			s.web.fail(err)
+			return
+		}
+		go s.dispatch(conn)
+	}
+}
+
+func (s *splitter) dispatch(conn net.Conn) {
+	reader := bufio.NewReaderSize(conn, len(http2Preface))
+	conn.SetReadDeadline(time.Now().Add(sniffTimeout))
+	start, err := reader.Peek(len(http2Preface))
+	conn.SetReadDeadline(time.Time{})
+	to := s.web
+	if bytes.Equal(start, http2Preface) {
+		to = s.grpc
+	} else if err != nil && len(start) == 0 {
+		log.Printf("Closing connection from %s, it sent nothing: %v\n", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	to.hand(&sniffedConn{Conn: conn, reader: reader})
+}
+
+// sniffedConn gives back the bytes looked at before reading on.
+type sniffedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// side is the listener of one protocol.
+type side struct {
+	splitter *splitter
+	conns    chan net.Conn
+	done     chan struct{}
+
+	once sync.Once
+	err  error
+}
+
+func (l *side) hand(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		conn.Close()
+	}
+}
+
+func (l *side) fail(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.done)
+	})
+}
+
+func (l *side) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+func (l *side) Close() error {
+	closed := false
+	l.once.Do(func() {
+		l.err = errors.New("use of closed network connection")
+		close(l.done)
+		closed = true
+	})
+	if !closed {
+		return nil
+	}
+	s := l.splitter
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed++; s.closed == 2 {
+		return s.lis.Close()
+	}
+	return nil
+}
+
+func (l *side) Addr() net.Addr {
+	return l.splitter.lis.Addr()
+}
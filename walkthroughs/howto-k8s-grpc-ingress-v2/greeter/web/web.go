@@ -0,0 +1,43 @@
+// Package web serves gRPC-Web, the variant of gRPC that browsers can send
+// over HTTP/1.1, next to native gRPC.
+package web
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// grpcWebHeaders are the request headers gRPC-Web clients send themselves.
+var grpcWebHeaders = []string{"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout"}
+
+// NewHandler serves gRPC-Web calls, in both the binary
+// application/grpc-web and the base64 application/grpc-web-text formats, by
+// handing them to s. Browsers may call it from allowedOrigins, or from
+// anywhere if that includes "*", and send allowedHeaders as metadata on top
+// of the headers gRPC-Web itself needs.
+//
+// s must not be the server that serves native gRPC: gRPC-Web calls go through
+// its ServeHTTP, and GracefulStop panics on calls that came in that way.
+func NewHandler(s *grpc.Server, allowedOrigins, allowedHeaders []string) http.Handler {
+	origins := make(map[string]bool)
+	for _, origin := range allowedOrigins {
+		origins[origin] = true
+	}
+	wrapped := grpcweb.WrapServer(s,
+		grpcweb.WithOriginFunc(func(origin string) bool {
+			return origins["*"] || origins[origin]
+		}),
+		grpcweb.WithAllowedRequestHeaders(append(grpcWebHeaders, allowedHeaders...)),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if wrapped.IsGrpcWebRequest(req) || wrapped.IsAcceptableGrpcCorsRequest(req) {
+			wrapped.ServeHTTP(w, req)
+			return
+		}
+		log.Printf("Rejected %s %s with content type %q, it isn't gRPC-Web\n", req.Method, req.URL.Path, req.Header.Get("Content-Type"))
+		http.Error(w, "only gRPC-Web is served here", http.StatusUnsupportedMediaType)
+	})
+}
@@ -0,0 +1,220 @@
+// webclient calls the greeter over gRPC-Web, framing the calls by hand the
+// way a browser library does, to check that gRPC-Web gets through to it, e.g.
+//
+//	go run ./webclient -url http://localhost:9111 -user Alice -text
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"greeter/input"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// methods are what webclient can call, with their request and a new reply.
+var methods = map[string]func(user string, echo bool, locale string) (proto.Message, func() proto.Message){
+	"/Hello/SayHello": func(user string, echo bool, locale string) (proto.Message, func() proto.Message) {
+		return &input.Name{User: user, Echo: echo}, func() proto.Message { return &input.Result{} }
+	},
+	"/greeter.v2.Greeter/SayHello": func(user string, echo bool, locale string) (proto.Message, func() proto.Message) {
+		return &input.HelloRequest{User: user}, func() proto.Message { return &input.HelloReply{} }
+	},
+	"/greeter.v2.Greeter/SayHelloStream": func(user string, echo bool, locale string) (proto.Message, func() proto.Message) {
+		return &input.HelloStreamRequest{User: user, Count: 3}, func() proto.Message { return &input.HelloReply{} }
+	},
+	"/greeter.v2.Greeter/SayHelloLocalized": func(user string, echo bool, locale string) (proto.Message, func() proto.Message) {
+		return &input.LocalizedHelloRequest{User: user, Locale: locale}, func() proto.Message { return &input.LocalizedHelloReply{} }
+	},
+}
+
+// headerList collects repeated -H flags.
+type headerList []string
+
+func (h *headerList) String() string     { return strings.Join(*h, ", ") }
+func (h *headerList) Set(v string) error { *h = append(*h, v); return nil }
+
+const (
+	frameTrailer    = 0x80
+	frameCompressed = 0x01
+)
+
+func frame(msg []byte) []byte {
+	b := make([]byte, 5+len(msg))
+	binary.BigEndian.PutUint32(b[1:], uint32(len(msg)))
+	copy(b[5:], msg)
+	return b
+}
+
+// decodeText decodes an application/grpc-web-text body, which the server
+// writes as base64 chunks that may each end in padding.
+func decodeText(body []byte) ([]byte, error) {
+	var decoded []byte
+	for len(body) > 0 {
+		end := bytes.IndexByte(body, '=')
+		if end < 0 {
+			end = len(body)
+		}
+		for end < len(body) && body[end] == '=' {
+			end++
+		}
+		chunk, err := base64.StdEncoding.DecodeString(string(body[:end]))
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, chunk...)
+		body = body[end:]
+	}
+	return decoded, nil
+}
+
+// readFrames splits a response body into its messages and trailers.
+func readFrames(body []byte) ([][]byte, http.Header, error) {
+	var messages [][]byte
+	trailers := http.Header{}
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, nil, errors.New("truncated frame header")
+		}
+		flags, size := body[0], binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < size {
+			return nil, nil, errors.New("truncated frame")
+		}
+		data := body[5 : 5+size]
+		body = body[5+size:]
+		switch {
+		case flags&frameTrailer != 0:
+			// trailers are an HTTP/1 header block without the blank line
+			block := append(append([]byte{}, data...), "\r\n"...)
+			tr, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(block))).ReadMIMEHeader()
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid trailers: %v", err)
+			}
+			for k, v := range tr {
+				trailers[k] = v
+			}
+		case flags&frameCompressed != 0:
+			return nil, nil, errors.New("compressed messages aren't supported")
+		default:
+			messages = append(messages, data)
+		}
+	}
+	return messages, trailers, nil
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:9111", "the greeter's gRPC-Web address")
+	method := flag.String("method", "/Hello/SayHello", "/Hello/SayHello, /greeter.v2.Greeter/SayHello, /greeter.v2.Greeter/SayHelloStream or /greeter.v2.Greeter/SayHelloLocalized")
+	user := flag.String("user", "web", "who to greet")
+	echo := flag.Bool("echo", false, "ask SayHello to echo the metadata it received")
+	locale := flag.String("locale", "en", "the locale to ask SayHelloLocalized for, e.g. es-MX")
+	text := flag.Bool("text", false, "use application/grpc-web-text, base64, instead of the binary format")
+	origin := flag.String("origin", "", "the Origin a browser would send, to check CORS")
+	caFile := flag.String("ca", "", "the CA bundle to verify an https url against")
+	var headers headerList
+	flag.Var(&headers, "H", "metadata to send, as key:value, may be repeated")
+	flag.Parse()
+
+	newCall, ok := methods[*method]
+	if !ok {
+		log.Fatalf("unknown method %s", *method)
+	}
+	req, newReply := newCall(*user, *echo, *locale)
+	msg, err := proto.Marshal(req)
+	if err != nil {
+		log.Fatalf("failed to marshal the request: %v", err)
+	}
+	body := frame(msg)
+	contentType := "application/grpc-web+proto"
+	if *text {
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+		contentType = "application/grpc-web-text"
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(*url, "/")+*method, bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("invalid url: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept", contentType)
+	httpReq.Header.Set("X-Grpc-Web", "1")
+	httpReq.Header.Set("X-User-Agent", "grpc-web-go-webclient")
+	if *origin != "" {
+		httpReq.Header.Set("Origin", *origin)
+	}
+	for _, h := range headers {
+		kv := strings.SplitN(h, ":", 2)
+		if len(kv) != 2 {
+			log.Fatalf("invalid header %q, it must be key:value", h)
+		}
+		httpReq.Header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+
+	client := http.DefaultClient
+	if *caFile != "" {
+		pem, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			log.Fatalf("failed to read the CA bundle: %v", err)
+		}
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(pem)
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		log.Fatalf("call failed: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Fatalf("failed to read the response: %v", err)
+	}
+
+	fmt.Printf("HTTP %s, %s, %s\n", resp.Status, resp.Proto, resp.Header.Get("Content-Type"))
+	for _, k := range []string{"Access-Control-Allow-Origin", "X-Greeter-Pod", "X-Greeter-Version", "Server"} {
+		if v := resp.Header.Get(k); v != "" {
+			fmt.Printf("%s: %s\n", k, v)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("not a gRPC-Web response: %s", strings.TrimSpace(string(respBody)))
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/grpc-web-text") {
+		if respBody, err = decodeText(respBody); err != nil {
+			log.Fatalf("invalid base64 response: %v", err)
+		}
+	}
+	messages, trailers, err := readFrames(respBody)
+	if err != nil {
+		log.Fatalf("invalid response: %v", err)
+	}
+	for _, m := range messages {
+		reply := newReply()
+		if err := proto.Unmarshal(m, reply); err != nil {
+			log.Fatalf("failed to unmarshal a reply: %v", err)
+		}
+		fmt.Printf("reply: %v\n", reply)
+	}
+	// a call that fails before any reply has its status in the headers
+	status, message := trailers.Get("Grpc-Status"), trailers.Get("Grpc-Message")
+	if status == "" {
+		status, message = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	fmt.Printf("grpc-status: %s %s\n", status, message)
+	if status != fmt.Sprint(int(codes.OK)) {
+		os.Exit(1)
+	}
+}
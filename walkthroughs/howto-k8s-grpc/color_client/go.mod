@@ -3,8 +3,8 @@ module github.com/aws/aws-app-mesh-examples/walkthroughs/howto-grpc/color_client
 go 1.13
 
 require (
-	github.com/golang/protobuf v1.5.3
 	golang.org/x/net v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98 // indirect
 	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.31.0
 )
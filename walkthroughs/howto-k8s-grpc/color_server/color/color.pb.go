@@ -1,28 +1,24 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.17.1
 // source: color.proto
 
 package color
 
 import (
-	context "context"
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
 type Color int32
 
@@ -39,610 +35,718 @@ const (
 	Color_WHITE    Color = 9
 )
 
-var Color_name = map[int32]string{
-	0: "NO_COLOR",
-	1: "RED",
-	2: "BLUE",
-	3: "GREEN",
-	4: "YELLOW",
-	5: "ORANGE",
-	6: "PURPLE",
-	7: "PINK",
-	8: "BLACK",
-	9: "WHITE",
-}
-
-var Color_value = map[string]int32{
-	"NO_COLOR": 0,
-	"RED":      1,
-	"BLUE":     2,
-	"GREEN":    3,
-	"YELLOW":   4,
-	"ORANGE":   5,
-	"PURPLE":   6,
-	"PINK":     7,
-	"BLACK":    8,
-	"WHITE":    9,
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "NO_COLOR",
+		1: "RED",
+		2: "BLUE",
+		3: "GREEN",
+		4: "YELLOW",
+		5: "ORANGE",
+		6: "PURPLE",
+		7: "PINK",
+		8: "BLACK",
+		9: "WHITE",
+	}
+	Color_value = map[string]int32{
+		"NO_COLOR": 0,
+		"RED":      1,
+		"BLUE":     2,
+		"GREEN":    3,
+		"YELLOW":   4,
+		"ORANGE":   5,
+		"PURPLE":   6,
+		"PINK":     7,
+		"BLACK":    8,
+		"WHITE":    9,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
 }
 
 func (x Color) String() string {
-	return proto.EnumName(Color_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Color) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{0}
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_color_proto_enumTypes[0].Descriptor()
 }
 
-type GetColorRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (Color) Type() protoreflect.EnumType {
+	return &file_color_proto_enumTypes[0]
 }
 
-func (m *GetColorRequest) Reset()         { *m = GetColorRequest{} }
-func (m *GetColorRequest) String() string { return proto.CompactTextString(m) }
-func (*GetColorRequest) ProtoMessage()    {}
-func (*GetColorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{0}
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (m *GetColorRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetColorRequest.Unmarshal(m, b)
-}
-func (m *GetColorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetColorRequest.Marshal(b, m, deterministic)
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{0}
 }
-func (m *GetColorRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetColorRequest.Merge(m, src)
+
+type GetColorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
-func (m *GetColorRequest) XXX_Size() int {
-	return xxx_messageInfo_GetColorRequest.Size(m)
+
+func (x *GetColorRequest) Reset() {
+	*x = GetColorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetColorRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetColorRequest.DiscardUnknown(m)
+
+func (x *GetColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_GetColorRequest proto.InternalMessageInfo
+func (*GetColorRequest) ProtoMessage() {}
 
-type GetColorResponse struct {
-	Color                Color    `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *GetColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetColorResponse) Reset()         { *m = GetColorResponse{} }
-func (m *GetColorResponse) String() string { return proto.CompactTextString(m) }
-func (*GetColorResponse) ProtoMessage()    {}
-func (*GetColorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{1}
+// Deprecated: Use GetColorRequest.ProtoReflect.Descriptor instead.
+func (*GetColorRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{0}
 }
 
-func (m *GetColorResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetColorResponse.Unmarshal(m, b)
-}
-func (m *GetColorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetColorResponse.Marshal(b, m, deterministic)
+type GetColorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
 }
-func (m *GetColorResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetColorResponse.Merge(m, src)
+
+func (x *GetColorResponse) Reset() {
+	*x = GetColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetColorResponse) XXX_Size() int {
-	return xxx_messageInfo_GetColorResponse.Size(m)
+
+func (x *GetColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *GetColorResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetColorResponse.DiscardUnknown(m)
+
+func (*GetColorResponse) ProtoMessage() {}
+
+func (x *GetColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_GetColorResponse proto.InternalMessageInfo
+// Deprecated: Use GetColorResponse.ProtoReflect.Descriptor instead.
+func (*GetColorResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *GetColorResponse) GetColor() Color {
-	if m != nil {
-		return m.Color
+func (x *GetColorResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
 	}
 	return Color_NO_COLOR
 }
 
 type SetColorRequest struct {
-	Color                Color    `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *SetColorRequest) Reset()         { *m = SetColorRequest{} }
-func (m *SetColorRequest) String() string { return proto.CompactTextString(m) }
-func (*SetColorRequest) ProtoMessage()    {}
-func (*SetColorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{2}
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
 }
 
-func (m *SetColorRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetColorRequest.Unmarshal(m, b)
-}
-func (m *SetColorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetColorRequest.Marshal(b, m, deterministic)
-}
-func (m *SetColorRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetColorRequest.Merge(m, src)
+func (x *SetColorRequest) Reset() {
+	*x = SetColorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SetColorRequest) XXX_Size() int {
-	return xxx_messageInfo_SetColorRequest.Size(m)
+
+func (x *SetColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SetColorRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetColorRequest.DiscardUnknown(m)
+
+func (*SetColorRequest) ProtoMessage() {}
+
+func (x *SetColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SetColorRequest proto.InternalMessageInfo
+// Deprecated: Use SetColorRequest.ProtoReflect.Descriptor instead.
+func (*SetColorRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{2}
+}
 
-func (m *SetColorRequest) GetColor() Color {
-	if m != nil {
-		return m.Color
+func (x *SetColorRequest) GetColor() Color {
+	if x != nil {
+		return x.Color
 	}
 	return Color_NO_COLOR
 }
 
 type SetColorResponse struct {
-	Color                Color    `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *SetColorResponse) Reset()         { *m = SetColorResponse{} }
-func (m *SetColorResponse) String() string { return proto.CompactTextString(m) }
-func (*SetColorResponse) ProtoMessage()    {}
-func (*SetColorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{3}
+	Color Color `protobuf:"varint,1,opt,name=color,proto3,enum=color.Color" json:"color,omitempty"`
 }
 
-func (m *SetColorResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetColorResponse.Unmarshal(m, b)
-}
-func (m *SetColorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetColorResponse.Marshal(b, m, deterministic)
-}
-func (m *SetColorResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetColorResponse.Merge(m, src)
+func (x *SetColorResponse) Reset() {
+	*x = SetColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SetColorResponse) XXX_Size() int {
-	return xxx_messageInfo_SetColorResponse.Size(m)
+
+func (x *SetColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SetColorResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetColorResponse.DiscardUnknown(m)
+
+func (*SetColorResponse) ProtoMessage() {}
+
+func (x *SetColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SetColorResponse proto.InternalMessageInfo
+// Deprecated: Use SetColorResponse.ProtoReflect.Descriptor instead.
+func (*SetColorResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{3}
+}
 
-func (m *SetColorResponse) GetColor() Color {
-	if m != nil {
-		return m.Color
+func (x *SetColorResponse) GetColor() Color {
+	if x != nil {
+		return x.Color
 	}
 	return Color_NO_COLOR
 }
 
 type Flakiness struct {
-	Rate                 float32  `protobuf:"fixed32,1,opt,name=rate,proto3" json:"rate,omitempty"`
-	Code                 int32    `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Flakiness) Reset()         { *m = Flakiness{} }
-func (m *Flakiness) String() string { return proto.CompactTextString(m) }
-func (*Flakiness) ProtoMessage()    {}
-func (*Flakiness) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{4}
+	Rate float32 `protobuf:"fixed32,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Code int32   `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
 }
 
-func (m *Flakiness) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Flakiness.Unmarshal(m, b)
-}
-func (m *Flakiness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Flakiness.Marshal(b, m, deterministic)
-}
-func (m *Flakiness) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Flakiness.Merge(m, src)
+func (x *Flakiness) Reset() {
+	*x = Flakiness{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Flakiness) XXX_Size() int {
-	return xxx_messageInfo_Flakiness.Size(m)
+
+func (x *Flakiness) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Flakiness) XXX_DiscardUnknown() {
-	xxx_messageInfo_Flakiness.DiscardUnknown(m)
+
+func (*Flakiness) ProtoMessage() {}
+
+func (x *Flakiness) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_Flakiness proto.InternalMessageInfo
+// Deprecated: Use Flakiness.ProtoReflect.Descriptor instead.
+func (*Flakiness) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{4}
+}
 
-func (m *Flakiness) GetRate() float32 {
-	if m != nil {
-		return m.Rate
+func (x *Flakiness) GetRate() float32 {
+	if x != nil {
+		return x.Rate
 	}
 	return 0
 }
 
-func (m *Flakiness) GetCode() int32 {
-	if m != nil {
-		return m.Code
+func (x *Flakiness) GetCode() int32 {
+	if x != nil {
+		return x.Code
 	}
 	return 0
 }
 
 type GetFlakinessRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *GetFlakinessRequest) Reset()         { *m = GetFlakinessRequest{} }
-func (m *GetFlakinessRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFlakinessRequest) ProtoMessage()    {}
-func (*GetFlakinessRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{5}
+func (x *GetFlakinessRequest) Reset() {
+	*x = GetFlakinessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *GetFlakinessRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFlakinessRequest.Unmarshal(m, b)
-}
-func (m *GetFlakinessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFlakinessRequest.Marshal(b, m, deterministic)
-}
-func (m *GetFlakinessRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFlakinessRequest.Merge(m, src)
-}
-func (m *GetFlakinessRequest) XXX_Size() int {
-	return xxx_messageInfo_GetFlakinessRequest.Size(m)
-}
-func (m *GetFlakinessRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFlakinessRequest.DiscardUnknown(m)
+func (x *GetFlakinessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_GetFlakinessRequest proto.InternalMessageInfo
+func (*GetFlakinessRequest) ProtoMessage() {}
 
-type GetFlakinessResponse struct {
-	Flakiness            *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (x *GetFlakinessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetFlakinessResponse) Reset()         { *m = GetFlakinessResponse{} }
-func (m *GetFlakinessResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFlakinessResponse) ProtoMessage()    {}
-func (*GetFlakinessResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{6}
+// Deprecated: Use GetFlakinessRequest.ProtoReflect.Descriptor instead.
+func (*GetFlakinessRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{5}
 }
 
-func (m *GetFlakinessResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFlakinessResponse.Unmarshal(m, b)
-}
-func (m *GetFlakinessResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFlakinessResponse.Marshal(b, m, deterministic)
-}
-func (m *GetFlakinessResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFlakinessResponse.Merge(m, src)
-}
-func (m *GetFlakinessResponse) XXX_Size() int {
-	return xxx_messageInfo_GetFlakinessResponse.Size(m)
-}
-func (m *GetFlakinessResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFlakinessResponse.DiscardUnknown(m)
-}
+type GetFlakinessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var xxx_messageInfo_GetFlakinessResponse proto.InternalMessageInfo
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+}
 
-func (m *GetFlakinessResponse) GetFlakiness() *Flakiness {
-	if m != nil {
-		return m.Flakiness
+func (x *GetFlakinessResponse) Reset() {
+	*x = GetFlakinessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-type SetFlakinessRequest struct {
-	Flakiness            *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (x *GetFlakinessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *SetFlakinessRequest) Reset()         { *m = SetFlakinessRequest{} }
-func (m *SetFlakinessRequest) String() string { return proto.CompactTextString(m) }
-func (*SetFlakinessRequest) ProtoMessage()    {}
-func (*SetFlakinessRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{7}
-}
+func (*GetFlakinessResponse) ProtoMessage() {}
 
-func (m *SetFlakinessRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetFlakinessRequest.Unmarshal(m, b)
-}
-func (m *SetFlakinessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetFlakinessRequest.Marshal(b, m, deterministic)
-}
-func (m *SetFlakinessRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetFlakinessRequest.Merge(m, src)
-}
-func (m *SetFlakinessRequest) XXX_Size() int {
-	return xxx_messageInfo_SetFlakinessRequest.Size(m)
-}
-func (m *SetFlakinessRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetFlakinessRequest.DiscardUnknown(m)
+func (x *GetFlakinessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SetFlakinessRequest proto.InternalMessageInfo
+// Deprecated: Use GetFlakinessResponse.ProtoReflect.Descriptor instead.
+func (*GetFlakinessResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *SetFlakinessRequest) GetFlakiness() *Flakiness {
-	if m != nil {
-		return m.Flakiness
+func (x *GetFlakinessResponse) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
 	}
 	return nil
 }
 
-type SetFlakinessResponse struct {
-	Flakiness            *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
-}
-
-func (m *SetFlakinessResponse) Reset()         { *m = SetFlakinessResponse{} }
-func (m *SetFlakinessResponse) String() string { return proto.CompactTextString(m) }
-func (*SetFlakinessResponse) ProtoMessage()    {}
-func (*SetFlakinessResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_83c7eb30498dece0, []int{8}
-}
+type SetFlakinessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *SetFlakinessResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetFlakinessResponse.Unmarshal(m, b)
-}
-func (m *SetFlakinessResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetFlakinessResponse.Marshal(b, m, deterministic)
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
 }
-func (m *SetFlakinessResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetFlakinessResponse.Merge(m, src)
-}
-func (m *SetFlakinessResponse) XXX_Size() int {
-	return xxx_messageInfo_SetFlakinessResponse.Size(m)
-}
-func (m *SetFlakinessResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetFlakinessResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_SetFlakinessResponse proto.InternalMessageInfo
 
-func (m *SetFlakinessResponse) GetFlakiness() *Flakiness {
-	if m != nil {
-		return m.Flakiness
+func (x *SetFlakinessRequest) Reset() {
+	*x = SetFlakinessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func init() {
-	proto.RegisterEnum("color.Color", Color_name, Color_value)
-	proto.RegisterType((*GetColorRequest)(nil), "color.GetColorRequest")
-	proto.RegisterType((*GetColorResponse)(nil), "color.GetColorResponse")
-	proto.RegisterType((*SetColorRequest)(nil), "color.SetColorRequest")
-	proto.RegisterType((*SetColorResponse)(nil), "color.SetColorResponse")
-	proto.RegisterType((*Flakiness)(nil), "color.Flakiness")
-	proto.RegisterType((*GetFlakinessRequest)(nil), "color.GetFlakinessRequest")
-	proto.RegisterType((*GetFlakinessResponse)(nil), "color.GetFlakinessResponse")
-	proto.RegisterType((*SetFlakinessRequest)(nil), "color.SetFlakinessRequest")
-	proto.RegisterType((*SetFlakinessResponse)(nil), "color.SetFlakinessResponse")
-}
-
-func init() { proto.RegisterFile("color.proto", fileDescriptor_83c7eb30498dece0) }
-
-var fileDescriptor_83c7eb30498dece0 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcf, 0x4f, 0xe2, 0x40,
-	0x1c, 0xc5, 0x69, 0xa1, 0xd0, 0x7e, 0x69, 0x96, 0xd9, 0x81, 0xdd, 0x25, 0xec, 0x85, 0xf4, 0x44,
-	0x3c, 0x70, 0x80, 0xc8, 0xcd, 0x03, 0xe0, 0x50, 0x09, 0x4d, 0x4b, 0x66, 0x24, 0xc4, 0x93, 0x41,
-	0x1c, 0x13, 0x22, 0xa1, 0xd8, 0x56, 0xfd, 0x7f, 0xfc, 0x4b, 0xcd, 0x4c, 0x8b, 0xe5, 0x97, 0x46,
-	0x8d, 0xb7, 0xd7, 0xd7, 0xf7, 0x3e, 0xf3, 0x9d, 0xce, 0x14, 0x8a, 0x73, 0x7f, 0xe9, 0x07, 0xcd,
-	0x75, 0xe0, 0x47, 0x3e, 0xd6, 0xe4, 0x83, 0xf5, 0x1b, 0x4a, 0x36, 0x8f, 0xfa, 0x42, 0x53, 0xfe,
-	0xf0, 0xc8, 0xc3, 0xc8, 0xea, 0x00, 0x4a, 0xad, 0x70, 0xed, 0xaf, 0x42, 0x8e, 0x2d, 0x88, 0xf3,
-	0x55, 0xa5, 0xae, 0x34, 0x7e, 0xb5, 0xcc, 0x66, 0x8c, 0x8a, 0x43, 0x09, 0xea, 0x14, 0x4a, 0x6c,
-	0x17, 0xf5, 0xa9, 0x5a, 0x07, 0x10, 0xfb, 0xce, 0x72, 0x6d, 0x30, 0x06, 0xcb, 0xd9, 0xfd, 0x62,
-	0xc5, 0xc3, 0x10, 0x63, 0xc8, 0x05, 0xb3, 0x88, 0xcb, 0xbc, 0x4a, 0xa5, 0x16, 0xde, 0xdc, 0xbf,
-	0xe5, 0x55, 0xb5, 0xae, 0x34, 0x34, 0x2a, 0xb5, 0xf5, 0x07, 0xca, 0x36, 0x8f, 0xde, 0x7a, 0x9b,
-	0x2d, 0x0f, 0xa0, 0xb2, 0x6b, 0x27, 0x73, 0x34, 0xc1, 0xb8, 0xdb, 0x98, 0x92, 0x5d, 0x6c, 0xa1,
-	0x64, 0x96, 0x34, 0x9c, 0x46, 0x2c, 0x02, 0x65, 0x76, 0x88, 0xff, 0x32, 0x66, 0x00, 0x15, 0xf6,
-	0x03, 0xe3, 0x9c, 0x3c, 0x83, 0x26, 0x3f, 0x19, 0x36, 0x41, 0x77, 0xbd, 0xeb, 0xbe, 0xe7, 0x78,
-	0x14, 0x65, 0x70, 0x01, 0xb2, 0x94, 0x9c, 0x23, 0x05, 0xeb, 0x90, 0xeb, 0x39, 0x13, 0x82, 0x54,
-	0x6c, 0x80, 0x66, 0x53, 0x42, 0x5c, 0x94, 0xc5, 0x00, 0xf9, 0x2b, 0xe2, 0x38, 0xde, 0x14, 0xe5,
-	0x84, 0xf6, 0x68, 0xd7, 0xb5, 0x09, 0xd2, 0x84, 0x1e, 0x4f, 0xe8, 0xd8, 0x21, 0x28, 0x2f, 0x8a,
-	0xe3, 0xa1, 0x3b, 0x42, 0x05, 0x51, 0xec, 0x39, 0xdd, 0xfe, 0x08, 0xe9, 0x42, 0x4e, 0x2f, 0x86,
-	0x97, 0x04, 0x19, 0xad, 0x17, 0x15, 0x4c, 0xb9, 0x32, 0xe3, 0xc1, 0xd3, 0x62, 0xce, 0xf1, 0x19,
-	0xe8, 0x9b, 0x3b, 0x85, 0xff, 0x26, 0x23, 0xef, 0xdd, 0xbb, 0xda, 0xbf, 0x03, 0x3f, 0xde, 0xb6,
-	0x95, 0x11, 0x75, 0xb6, 0x5f, 0x67, 0xef, 0xd4, 0xd9, 0x61, 0x7d, 0x08, 0xe6, 0xf6, 0xf1, 0xe2,
-	0x5a, 0xba, 0xd2, 0xfe, 0x59, 0xd5, 0xfe, 0x1f, 0x7d, 0xb7, 0x8d, 0x62, 0xc7, 0x50, 0xec, 0x03,
-	0x14, 0x3b, 0x8a, 0xba, 0xc9, 0xcb, 0x1f, 0xb1, 0xfd, 0x1a, 0x00, 0x00, 0xff, 0xff, 0xc3, 0x77,
-	0xf0, 0xd9, 0x97, 0x03, 0x00, 0x00,
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ColorServiceClient is the client API for ColorService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ColorServiceClient interface {
-	GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error)
-	SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error)
-	GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error)
-	SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error)
-}
-
-type colorServiceClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewColorServiceClient(cc *grpc.ClientConn) ColorServiceClient {
-	return &colorServiceClient{cc}
-}
-
-func (c *colorServiceClient) GetColor(ctx context.Context, in *GetColorRequest, opts ...grpc.CallOption) (*GetColorResponse, error) {
-	out := new(GetColorResponse)
-	err := c.cc.Invoke(ctx, "/color.ColorService/GetColor", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *SetFlakinessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *colorServiceClient) SetColor(ctx context.Context, in *SetColorRequest, opts ...grpc.CallOption) (*SetColorResponse, error) {
-	out := new(SetColorResponse)
-	err := c.cc.Invoke(ctx, "/color.ColorService/SetColor", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*SetFlakinessRequest) ProtoMessage() {}
+
+func (x *SetFlakinessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return out, nil
+	return mi.MessageOf(x)
 }
 
-func (c *colorServiceClient) GetFlakiness(ctx context.Context, in *GetFlakinessRequest, opts ...grpc.CallOption) (*GetFlakinessResponse, error) {
-	out := new(GetFlakinessResponse)
-	err := c.cc.Invoke(ctx, "/color.ColorService/GetFlakiness", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// Deprecated: Use SetFlakinessRequest.ProtoReflect.Descriptor instead.
+func (*SetFlakinessRequest) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{7}
 }
 
-func (c *colorServiceClient) SetFlakiness(ctx context.Context, in *SetFlakinessRequest, opts ...grpc.CallOption) (*SetFlakinessResponse, error) {
-	out := new(SetFlakinessResponse)
-	err := c.cc.Invoke(ctx, "/color.ColorService/SetFlakiness", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *SetFlakinessRequest) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
 	}
-	return out, nil
+	return nil
 }
 
-// ColorServiceServer is the server API for ColorService service.
-type ColorServiceServer interface {
-	GetColor(context.Context, *GetColorRequest) (*GetColorResponse, error)
-	SetColor(context.Context, *SetColorRequest) (*SetColorResponse, error)
-	GetFlakiness(context.Context, *GetFlakinessRequest) (*GetFlakinessResponse, error)
-	SetFlakiness(context.Context, *SetFlakinessRequest) (*SetFlakinessResponse, error)
-}
+type SetFlakinessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-// UnimplementedColorServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedColorServiceServer struct {
+	Flakiness *Flakiness `protobuf:"bytes,1,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
 }
 
-func (*UnimplementedColorServiceServer) GetColor(ctx context.Context, req *GetColorRequest) (*GetColorResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetColor not implemented")
-}
-func (*UnimplementedColorServiceServer) SetColor(ctx context.Context, req *SetColorRequest) (*SetColorResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetColor not implemented")
-}
-func (*UnimplementedColorServiceServer) GetFlakiness(ctx context.Context, req *GetFlakinessRequest) (*GetFlakinessResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFlakiness not implemented")
-}
-func (*UnimplementedColorServiceServer) SetFlakiness(ctx context.Context, req *SetFlakinessRequest) (*SetFlakinessResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetFlakiness not implemented")
+func (x *SetFlakinessResponse) Reset() {
+	*x = SetFlakinessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_color_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func RegisterColorServiceServer(s *grpc.Server, srv ColorServiceServer) {
-	s.RegisterService(&_ColorService_serviceDesc, srv)
+func (x *SetFlakinessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func _ColorService_GetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetColorRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ColorServiceServer).GetColor(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/color.ColorService/GetColor",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ColorServiceServer).GetColor(ctx, req.(*GetColorRequest))
+func (*SetFlakinessResponse) ProtoMessage() {}
+
+func (x *SetFlakinessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_color_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return interceptor(ctx, in, info, handler)
+	return mi.MessageOf(x)
 }
 
-func _ColorService_SetColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetColorRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ColorServiceServer).SetColor(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/color.ColorService/SetColor",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ColorServiceServer).SetColor(ctx, req.(*SetColorRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// Deprecated: Use SetFlakinessResponse.ProtoReflect.Descriptor instead.
+func (*SetFlakinessResponse) Descriptor() ([]byte, []int) {
+	return file_color_proto_rawDescGZIP(), []int{8}
 }
 
-func _ColorService_GetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFlakinessRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ColorServiceServer).GetFlakiness(ctx, in)
+func (x *SetFlakinessResponse) GetFlakiness() *Flakiness {
+	if x != nil {
+		return x.Flakiness
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/color.ColorService/GetFlakiness",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ColorServiceServer).GetFlakiness(ctx, req.(*GetFlakinessRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _ColorService_SetFlakiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetFlakinessRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ColorServiceServer).SetFlakiness(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/color.ColorService/SetFlakiness",
+var File_color_proto protoreflect.FileDescriptor
+
+var file_color_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22,
+	0x35, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
+	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x36, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x33,
+	0x0a, 0x09, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x46, 0x6c,
+	0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x22, 0x45, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09,
+	0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x46, 0x0a, 0x14, 0x53, 0x65, 0x74,
+	0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x46, 0x6c, 0x61,
+	0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x2a, 0x77, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f,
+	0x5f, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4c, 0x55, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x47,
+	0x52, 0x45, 0x45, 0x4e, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x59, 0x45, 0x4c, 0x4c, 0x4f, 0x57,
+	0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x05, 0x12, 0x0a,
+	0x0a, 0x06, 0x50, 0x55, 0x52, 0x50, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x49,
+	0x4e, 0x4b, 0x10, 0x07, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x08, 0x12,
+	0x09, 0x0a, 0x05, 0x57, 0x48, 0x49, 0x54, 0x45, 0x10, 0x09, 0x32, 0xa2, 0x02, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x08, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53,
+	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x69,
+	0x6e, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74,
+	0x46, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x6b,
+	0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_color_proto_rawDescOnce sync.Once
+	file_color_proto_rawDescData = file_color_proto_rawDesc
+)
+
+func file_color_proto_rawDescGZIP() []byte {
+	file_color_proto_rawDescOnce.Do(func() {
+		file_color_proto_rawDescData = protoimpl.X.CompressGZIP(file_color_proto_rawDescData)
+	})
+	return file_color_proto_rawDescData
+}
+
+var file_color_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_color_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_color_proto_goTypes = []interface{}{
+	(Color)(0),                   // 0: color.Color
+	(*GetColorRequest)(nil),      // 1: color.GetColorRequest
+	(*GetColorResponse)(nil),     // 2: color.GetColorResponse
+	(*SetColorRequest)(nil),      // 3: color.SetColorRequest
+	(*SetColorResponse)(nil),     // 4: color.SetColorResponse
+	(*Flakiness)(nil),            // 5: color.Flakiness
+	(*GetFlakinessRequest)(nil),  // 6: color.GetFlakinessRequest
+	(*GetFlakinessResponse)(nil), // 7: color.GetFlakinessResponse
+	(*SetFlakinessRequest)(nil),  // 8: color.SetFlakinessRequest
+	(*SetFlakinessResponse)(nil), // 9: color.SetFlakinessResponse
+}
+var file_color_proto_depIdxs = []int32{
+	0,  // 0: color.GetColorResponse.color:type_name -> color.Color
+	0,  // 1: color.SetColorRequest.color:type_name -> color.Color
+	0,  // 2: color.SetColorResponse.color:type_name -> color.Color
+	5,  // 3: color.GetFlakinessResponse.flakiness:type_name -> color.Flakiness
+	5,  // 4: color.SetFlakinessRequest.flakiness:type_name -> color.Flakiness
+	5,  // 5: color.SetFlakinessResponse.flakiness:type_name -> color.Flakiness
+	1,  // 6: color.ColorService.GetColor:input_type -> color.GetColorRequest
+	3,  // 7: color.ColorService.SetColor:input_type -> color.SetColorRequest
+	6,  // 8: color.ColorService.GetFlakiness:input_type -> color.GetFlakinessRequest
+	8,  // 9: color.ColorService.SetFlakiness:input_type -> color.SetFlakinessRequest
+	2,  // 10: color.ColorService.GetColor:output_type -> color.GetColorResponse
+	4,  // 11: color.ColorService.SetColor:output_type -> color.SetColorResponse
+	7,  // 12: color.ColorService.GetFlakiness:output_type -> color.GetFlakinessResponse
+	9,  // 13: color.ColorService.SetFlakiness:output_type -> color.SetFlakinessResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_color_proto_init() }
+func file_color_proto_init() {
+	if File_color_proto != nil {
+		return
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ColorServiceServer).SetFlakiness(ctx, req.(*SetFlakinessRequest))
+	if !protoimpl.UnsafeEnabled {
+		file_color_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetColorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetColorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetColorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetColorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Flakiness); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlakinessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlakinessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFlakinessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_color_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFlakinessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _ColorService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "color.ColorService",
-	HandlerType: (*ColorServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetColor",
-			Handler:    _ColorService_GetColor_Handler,
-		},
-		{
-			MethodName: "SetColor",
-			Handler:    _ColorService_SetColor_Handler,
-		},
-		{
-			MethodName: "GetFlakiness",
-			Handler:    _ColorService_GetFlakiness_Handler,
-		},
-		{
-			MethodName: "SetFlakiness",
-			Handler:    _ColorService_SetFlakiness_Handler,
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_color_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
 		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "color.proto",
+		GoTypes:           file_color_proto_goTypes,
+		DependencyIndexes: file_color_proto_depIdxs,
+		EnumInfos:         file_color_proto_enumTypes,
+		MessageInfos:      file_color_proto_msgTypes,
+	}.Build()
+	File_color_proto = out.File
+	file_color_proto_rawDesc = nil
+	file_color_proto_goTypes = nil
+	file_color_proto_depIdxs = nil
 }
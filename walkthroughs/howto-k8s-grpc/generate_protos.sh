@@ -2,5 +2,9 @@
 
 set -e
 
-protoc ./color.proto --go_out=plugins=grpc:./color_client/color
-protoc ./color.proto --go_out=plugins=grpc:./color_server/color
+# The checked-in code was generated with protoc 3.17.1 and these plugins:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.31.0
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
+for out in ./color_client/color ./color_server/color; do
+    protoc ./color.proto --go_out=$out --go-grpc_out=$out
+done
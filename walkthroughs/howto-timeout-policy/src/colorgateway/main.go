@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -12,6 +13,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-xray-sdk-go/xray"
 	"github.com/pkg/errors"
@@ -54,16 +56,10 @@ func getColorTellerEndpoint() (string, error) {
 type colorHandler struct{}
 
 func (h *colorHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	color, err, resCode, body := getColorFromColorTeller(request)
-	
-	if err != nil {
-		writer.WriteHeader(http.StatusInternalServerError)
-		writer.Write([]byte("500 - Unexpected Error"))
-		return
-	}
-	if resCode != 200 {
-        writer.WriteHeader(resCode)
-		writer.Write(body)
+	color, failure := getColorFromColorTeller(request)
+	if failure != nil {
+		log.Printf("Failed to get color: %s", failure)
+		failure.write(writer)
 		return
 	}
 
@@ -122,40 +118,58 @@ func (h *clearColorStatsHandler) ServeHTTP(writer http.ResponseWriter, request *
 	fmt.Fprint(writer, "cleared")
 }
 
-func getColorFromColorTeller(request *http.Request) (string, error, int, []byte) {
+func getColorFromColorTeller(request *http.Request) (string, *colorTellerFailure) {
+	start := time.Now()
 	colorTellerEndpoint, err := getColorTellerEndpoint()
 	if err != nil {
-		return "-n/a-", err, 0, nil
+		return "-n/a-", newColorTellerFailure(failureConfigError, http.StatusInternalServerError, time.Since(start), 0, err.Error())
+	}
+	deadline, err := getRequestDeadline(request)
+	if err != nil {
+		return "-n/a-", newColorTellerFailure(failureBadRequest, http.StatusBadRequest, time.Since(start), 0, err.Error())
+	}
+
+	ctx := request.Context()
+	if deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, deadline)
+		defer cancel()
 	}
 
 	client := xray.Client(&http.Client{})
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s", colorTellerEndpoint), nil)
 	if err != nil {
-		return "-n/a-", err, 0, nil
+		return "-n/a-", newColorTellerFailure(failureConfigError, http.StatusInternalServerError, time.Since(start), deadline, err.Error())
 	}
 	log.Println("Getting Latency: ", request.Header.Get("Latency"))
 	req.Header.Set("Latency", request.Header.Get("Latency"))
 	log.Println("Setting Latency: ", req.Header.Get("Latency"))
-	resp, err := client.Do(req.WithContext(request.Context()))
+	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
-		return "-n/a-", err, resp.StatusCode, nil
+		kind, statusCode := classifyError(request, ctx, err)
+		return "-n/a-", newColorTellerFailure(kind, statusCode, time.Since(start), deadline, err.Error())
 	}
 
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "-n/a-", err, resp.StatusCode, nil
+		kind, statusCode := classifyError(request, ctx, err)
+		return "-n/a-", newColorTellerFailure(kind, statusCode, time.Since(start), deadline, err.Error())
 	}
 	if resp.StatusCode != 200 {
-		return "-n/a-", nil, resp.StatusCode, body
+		kind := failureUpstreamError
+		if isEnvoyTimeout(resp, body) {
+			kind = failureEnvoyTimeout
+		}
+		return "-n/a-", newColorTellerFailure(kind, resp.StatusCode, time.Since(start), deadline, strings.TrimSpace(string(body)))
 	}
 
 	color := strings.TrimSpace(string(body))
 	if len(color) < 1 {
-		return "-n/a-", errors.New("Empty response from colorTeller"), resp.StatusCode, nil
+		return "-n/a-", newColorTellerFailure(failureUpstreamError, http.StatusBadGateway, time.Since(start), deadline, "Empty response from colorTeller")
 	}
 
-	return color, nil, resp.StatusCode, nil
+	return color, nil
 }
 
 func getTCPEchoEndpoint() (string, error) {
@@ -218,6 +232,10 @@ func main() {
 	if err != nil {
 		log.Fatalln(err)
 	}
+	colorTellerTimeout, err = getColorTellerTimeout()
+	if err != nil {
+		log.Fatalln(err)
+	}
 	tcpEchoEndpoint, err := getTCPEchoEndpoint()
 	if err != nil {
 		log.Println(err)
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Which layer ended a failed call to the colorteller.
+const (
+	failureClientCancelled = "client_cancelled"
+	failureAppTimeout      = "app_timeout"
+	failureEnvoyTimeout    = "envoy_timeout"
+	failureUpstreamError   = "upstream_error"
+	failureBadRequest      = "bad_request"
+	failureConfigError     = "config_error"
+)
+
+// statusClientClosedRequest is not a standard code, but it is the one
+// commonly logged when the client goes away before the response is ready.
+const statusClientClosedRequest = 499
+
+// colorTellerFailure describes why a call to the colorteller failed and how
+// long it took to fail.
+type colorTellerFailure struct {
+	Kind       string `json:"error"`
+	StatusCode int    `json:"statusCode"`
+	Elapsed    string `json:"elapsed"`
+	ElapsedMs  int64  `json:"elapsedMs"`
+	Deadline   string `json:"deadline,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+func (f *colorTellerFailure) write(writer http.ResponseWriter) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(f.StatusCode)
+	json.NewEncoder(writer).Encode(f)
+}
+
+// colorTellerTimeout is the default app-side timeout for calls to the
+// colorteller, read once at startup. Zero means the gateway waits as long as
+// its client does.
+var colorTellerTimeout time.Duration
+
+func getColorTellerTimeout() (time.Duration, error) {
+	timeout := os.Getenv("COLOR_TELLER_TIMEOUT")
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d < 0 {
+		return 0, errors.Errorf("invalid COLOR_TELLER_TIMEOUT %q", timeout)
+	}
+	return d, nil
+}
+
+// getRequestDeadline works out how long the gateway may spend calling the
+// colorteller. A Timeout header from the client wins over
+// COLOR_TELLER_TIMEOUT. The timeout Envoy expects for the inbound request is
+// deliberately not used: a deadline that fires together with Envoy's route
+// timeout couldn't be told apart from it.
+func getRequestDeadline(request *http.Request) (time.Duration, error) {
+	if timeout := request.Header.Get("Timeout"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return 0, errors.Errorf("invalid Timeout header %q", timeout)
+		}
+		return d, nil
+	}
+	return colorTellerTimeout, nil
+}
+
+// isEnvoyTimeout recognizes the local reply Envoy sends when its route
+// timeout fires before the upstream answers.
+func isEnvoyTimeout(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		return false
+	}
+	if strings.Contains(string(body), "upstream request timeout") {
+		return true
+	}
+	// x-envoy-upstream-service-time is only added when the upstream itself
+	// answered, so a 504 with it came from the colorteller, not from Envoy.
+	if resp.Header.Get("x-envoy-upstream-service-time") != "" {
+		return false
+	}
+	if resp.Header.Get("Server") == "envoy" {
+		return true
+	}
+	for name := range resp.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-envoy-") {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyError attributes a transport error to the client going away or to
+// the gateway's own deadline; anything else is an upstream error.
+func classifyError(request *http.Request, ctx context.Context, err error) (string, int) {
+	if errors.Is(request.Context().Err(), context.Canceled) {
+		return failureClientCancelled, statusClientClosedRequest
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return failureAppTimeout, http.StatusGatewayTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return failureAppTimeout, http.StatusGatewayTimeout
+	}
+	return failureUpstreamError, http.StatusBadGateway
+}
+
+func newColorTellerFailure(kind string, statusCode int, elapsed, deadline time.Duration, detail string) *colorTellerFailure {
+	f := &colorTellerFailure{
+		Kind:       kind,
+		StatusCode: statusCode,
+		Elapsed:    elapsed.Round(time.Millisecond).String(),
+		ElapsedMs:  elapsed.Milliseconds(),
+		Detail:     detail,
+	}
+	if deadline > 0 {
+		f.Deadline = deadline.String()
+	}
+	return f
+}
+
+func (f *colorTellerFailure) String() string {
+	return fmt.Sprintf("%s (status %d) after %s: %s", f.Kind, f.StatusCode, f.Elapsed, f.Detail)
+}
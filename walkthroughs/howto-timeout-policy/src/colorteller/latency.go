@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultParetoScale = 100 * time.Millisecond
+const defaultParetoAlpha = 1.16
+
+// maxLatency bounds every configured latency and every sample, well past any
+// timeout worth testing, so the arithmetic below can't overflow.
+const maxLatency = 10 * time.Minute
+
+// latencySpec produces the delay to inject for one request.
+type latencySpec interface {
+	sample() time.Duration
+}
+
+type fixedLatency time.Duration
+
+func (l fixedLatency) sample() time.Duration {
+	return time.Duration(l)
+}
+
+type uniformLatency struct {
+	min, max time.Duration
+}
+
+func (l uniformLatency) sample() time.Duration {
+	return l.min + time.Duration(rand.Int63n(int64(l.max-l.min)+1))
+}
+
+type normalLatency struct {
+	mean, stddev time.Duration
+}
+
+func (l normalLatency) sample() time.Duration {
+	return clampLatency(rand.NormFloat64()*float64(l.stddev) + float64(l.mean))
+}
+
+type paretoLatency struct {
+	scale time.Duration
+	alpha float64
+}
+
+func (l paretoLatency) sample() time.Duration {
+	// inverse transform sampling, 1-U is in (0, 1] so the result is finite,
+	// though it can be far too large for a Duration with a small alpha
+	return clampLatency(float64(l.scale) / math.Pow(1-rand.Float64(), 1/l.alpha))
+}
+
+// clampLatency converts a sample in nanoseconds to a Duration between 0 and
+// maxLatency.
+func clampLatency(nanos float64) time.Duration {
+	if nanos <= 0 {
+		return 0
+	}
+	if nanos >= float64(maxLatency) {
+		return maxLatency
+	}
+	return time.Duration(nanos)
+}
+
+// percentileLatency delays only the requests above the given percentile,
+// e.g. p99 delays one request in a hundred by slow and the rest by base.
+type percentileLatency struct {
+	percentile float64
+	slow       time.Duration
+	base       time.Duration
+}
+
+func (l percentileLatency) sample() time.Duration {
+	if rand.Float64()*100 >= l.percentile {
+		return l.slow
+	}
+	return l.base
+}
+
+// parseLatency understands the Latency header formats:
+//
+//	7                   whole seconds, as before
+//	250ms               a Go duration
+//	uniform:100ms-2s    uniformly distributed between min and max
+//	normal:500ms,100ms  normally distributed with mean and standard deviation
+//	pareto:100ms,1.5    Pareto distributed with scale and shape (alpha)
+//	pareto              Pareto distributed with the 80/20 defaults below
+//	p99:2s              2s for requests above the 99th percentile, none otherwise
+//	p95:2s,50ms         2s above the 95th percentile, 50ms otherwise
+func parseLatency(value string) (latencySpec, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 || seconds > int(maxLatency/time.Second) {
+			return nil, fmt.Errorf("latency must be between 0 and %d seconds", int(maxLatency/time.Second))
+		}
+		return fixedLatency(time.Duration(seconds) * time.Second), nil
+	}
+
+	kind, args := value, ""
+	if i := strings.Index(value, ":"); i >= 0 {
+		kind, args = value[:i], value[i+1:]
+	}
+	switch {
+	case kind == "uniform":
+		parts := strings.Split(args, "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("uniform latency must look like uniform:100ms-2s")
+		}
+		min, err := parseLatencyDuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		max, err := parseLatencyDuration(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if max < min {
+			return nil, fmt.Errorf("uniform latency max %s is less than min %s", max, min)
+		}
+		return uniformLatency{min: min, max: max}, nil
+	case kind == "normal":
+		parts := strings.Split(args, ",")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("normal latency must look like normal:500ms,100ms")
+		}
+		mean, err := parseLatencyDuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		stddev, err := parseLatencyDuration(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return normalLatency{mean: mean, stddev: stddev}, nil
+	case kind == "pareto":
+		if args == "" {
+			return paretoLatency{scale: defaultParetoScale, alpha: defaultParetoAlpha}, nil
+		}
+		parts := strings.Split(args, ",")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("pareto latency must look like pareto:100ms,1.5")
+		}
+		scale, err := parseLatencyDuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		alpha, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || alpha <= 0 {
+			return nil, fmt.Errorf("pareto alpha must be a positive number, got %q", parts[1])
+		}
+		return paretoLatency{scale: scale, alpha: alpha}, nil
+	case strings.HasPrefix(kind, "p") && args != "":
+		percentile, err := strconv.ParseFloat(kind[1:], 64)
+		if err != nil || percentile <= 0 || percentile >= 100 {
+			return nil, fmt.Errorf("percentile must be between 0 and 100 exclusive, got %q", kind)
+		}
+		parts := strings.Split(args, ",")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("percentile latency must look like p99:2s or p99:2s,50ms")
+		}
+		slow, err := parseLatencyDuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		spec := percentileLatency{percentile: percentile, slow: slow}
+		if len(parts) == 2 {
+			if spec.base, err = parseLatencyDuration(parts[1]); err != nil {
+				return nil, err
+			}
+		}
+		return spec, nil
+	case args == "":
+		d, err := parseLatencyDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return fixedLatency(d), nil
+	}
+	return nil, fmt.Errorf("unknown latency distribution %q", kind)
+}
+
+func parseLatencyDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d > maxLatency {
+		return 0, fmt.Errorf("latency must be between 0 and %s, got %s", maxLatency, d)
+	}
+	return d, nil
+}
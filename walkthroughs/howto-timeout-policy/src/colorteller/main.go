@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"os"
 	"time"
-	"strconv"
 
 	"github.com/aws/aws-xray-sdk-go/xray"
 )
@@ -45,23 +44,25 @@ func getStage() string {
 type colorHandler struct{}
 func (h *colorHandler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 	log.Println("color requested, checking for Latency")
-	// log.Println("color requested, responding with", getColor())
-	
+
 	latency := req.Header.Get("Latency")
-	log.Println(latency)
 	if latency != "" {
-		log.Println("got Latency")
-		latencyValue, err := strconv.Atoi(latency)
-		if err != nil{
-			return 
+		spec, err := parseLatency(latency)
+		if err != nil {
+			log.Printf("invalid Latency %q: %v", latency, err)
+			http.Error(writer, fmt.Sprintf("invalid Latency header %q: %v", latency, err), http.StatusBadRequest)
+			return
+		}
+		delay := spec.sample()
+		log.Printf("got Latency %q, waiting for %s", latency, delay)
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			log.Printf("request cancelled after waiting less than %s: %v", delay, req.Context().Err())
+			return
 		}
-		latencyDuration := time.Duration(latencyValue)
-		log.Println("waiting for ", latencyValue)
-		time.Sleep(latencyDuration * time.Second)
 	}
-    fmt.Fprint(writer, getColor())
-	
-	
+	fmt.Fprint(writer, getColor())
 }
 
 type pingHandler struct{}